@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	proto "github.com/golang/protobuf/proto"
 	_ "github.com/golang/protobuf/ptypes/timestamp"
@@ -127,10 +128,18 @@ func (m *ImageListResponse) GetImages() []*v1alpha2.Image {
 }
 
 type ImagePullRequest struct {
-	Image                *v1alpha2.ImageSpec  `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Auth                 *v1alpha2.AuthConfig `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Image *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Credentials for the registries involved, matched by server address.
+	Auth []*v1alpha2.AuthConfig `protobuf:"bytes,2,rep,name=auth,proto3" json:"auth,omitempty"`
+	// Platform to pull, e.g. linux/arm64, defaults to that of the builder.
+	Platform string `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	// Pull all platforms of a multi-platform image.
+	AllPlatforms bool `protobuf:"varint,4,opt,name=all_platforms,json=allPlatforms,proto3" json:"all_platforms,omitempty"`
+	// ServiceAccount, as namespace/name or name in the k3c namespace, whose imagePullSecrets are used for the pull.
+	// Defaults to the default ServiceAccount of the k3c namespace.
+	ServiceAccount       string   `protobuf:"bytes,5,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ImagePullRequest) Reset()      { *m = ImagePullRequest{} }
@@ -172,17 +181,41 @@ func (m *ImagePullRequest) GetImage() *v1alpha2.ImageSpec {
 	return nil
 }
 
-func (m *ImagePullRequest) GetAuth() *v1alpha2.AuthConfig {
+func (m *ImagePullRequest) GetAuth() []*v1alpha2.AuthConfig {
 	if m != nil {
 		return m.Auth
 	}
 	return nil
 }
 
+func (m *ImagePullRequest) GetPlatform() string {
+	if m != nil {
+		return m.Platform
+	}
+	return ""
+}
+
+func (m *ImagePullRequest) GetAllPlatforms() bool {
+	if m != nil {
+		return m.AllPlatforms
+	}
+	return false
+}
+
+func (m *ImagePullRequest) GetServiceAccount() string {
+	if m != nil {
+		return m.ServiceAccount
+	}
+	return ""
+}
+
 type ImagePullResponse struct {
-	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Name of the image pulled, only set in the final response.
+	Image string `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Progress of the pull.
+	Status               []ImageStatus `protobuf:"bytes,2,rep,name=status,proto3" json:"status"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *ImagePullResponse) Reset()      { *m = ImagePullResponse{} }
@@ -224,11 +257,25 @@ func (m *ImagePullResponse) GetImage() string {
 	return ""
 }
 
+func (m *ImagePullResponse) GetStatus() []ImageStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
 type ImagePushRequest struct {
-	Image                *v1alpha2.ImageSpec  `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Auth                 *v1alpha2.AuthConfig `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Image *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Credentials for the registries involved, matched by server address.
+	Auth []*v1alpha2.AuthConfig `protobuf:"bytes,2,rep,name=auth,proto3" json:"auth,omitempty"`
+	// References to push the image to, in order, defaults to the image itself.
+	Targets []string `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`
+	// Compression of the layers pushed: gzip, zstd or estargz, defaults to the layers as stored.
+	Compression string `protobuf:"bytes,4,opt,name=compression,proto3" json:"compression,omitempty"`
+	// Recompress layers already compressed as requested.
+	ForceCompression     bool     `protobuf:"varint,5,opt,name=force_compression,json=forceCompression,proto3" json:"force_compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ImagePushRequest) Reset()      { *m = ImagePushRequest{} }
@@ -270,17 +317,43 @@ func (m *ImagePushRequest) GetImage() *v1alpha2.ImageSpec {
 	return nil
 }
 
-func (m *ImagePushRequest) GetAuth() *v1alpha2.AuthConfig {
+func (m *ImagePushRequest) GetAuth() []*v1alpha2.AuthConfig {
 	if m != nil {
 		return m.Auth
 	}
 	return nil
 }
 
+func (m *ImagePushRequest) GetTargets() []string {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *ImagePushRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *ImagePushRequest) GetForceCompression() bool {
+	if m != nil {
+		return m.ForceCompression
+	}
+	return false
+}
+
 type ImagePushResponse struct {
-	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Name of the image pushed, only set in the final response.
+	Image string `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// References the image was pushed to, only set in the final response.
+	Targets []string `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	// Progress of the push.
+	Status               []ImageStatus `protobuf:"bytes,3,rep,name=status,proto3" json:"status"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *ImagePushResponse) Reset()      { *m = ImagePushResponse{} }
@@ -322,90 +395,14 @@ func (m *ImagePushResponse) GetImage() string {
 	return ""
 }
 
-type ImageProgressRequest struct {
-	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *ImageProgressRequest) Reset()      { *m = ImageProgressRequest{} }
-func (*ImageProgressRequest) ProtoMessage() {}
-func (*ImageProgressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{6}
-}
-func (m *ImageProgressRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ImageProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ImageProgressRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *ImageProgressRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImageProgressRequest.Merge(m, src)
-}
-func (m *ImageProgressRequest) XXX_Size() int {
-	return m.Size()
-}
-func (m *ImageProgressRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImageProgressRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ImageProgressRequest proto.InternalMessageInfo
-
-func (m *ImageProgressRequest) GetImage() string {
+func (m *ImagePushResponse) GetTargets() []string {
 	if m != nil {
-		return m.Image
-	}
-	return ""
-}
-
-type ImageProgressResponse struct {
-	Status               []ImageStatus `protobuf:"bytes,1,rep,name=status,proto3" json:"status"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
-}
-
-func (m *ImageProgressResponse) Reset()      { *m = ImageProgressResponse{} }
-func (*ImageProgressResponse) ProtoMessage() {}
-func (*ImageProgressResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{7}
-}
-func (m *ImageProgressResponse) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ImageProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ImageProgressResponse.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+		return m.Targets
 	}
+	return nil
 }
-func (m *ImageProgressResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImageProgressResponse.Merge(m, src)
-}
-func (m *ImageProgressResponse) XXX_Size() int {
-	return m.Size()
-}
-func (m *ImageProgressResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImageProgressResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ImageProgressResponse proto.InternalMessageInfo
 
-func (m *ImageProgressResponse) GetStatus() []ImageStatus {
+func (m *ImagePushResponse) GetStatus() []ImageStatus {
 	if m != nil {
 		return m.Status
 	}
@@ -427,7 +424,7 @@ type ImageStatus struct {
 func (m *ImageStatus) Reset()      { *m = ImageStatus{} }
 func (*ImageStatus) ProtoMessage() {}
 func (*ImageStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{8}
+	return fileDescriptor_51c65cb1807988f9, []int{6}
 }
 func (m *ImageStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,15 +497,17 @@ func (m *ImageStatus) GetUpdatedAt() time.Time {
 
 type ImageRemoveRequest struct {
 	// Spec of the image to remove.
-	Image                *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Image *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Remove the image even if used by containers or, when removing by ID, tagged in multiple repositories.
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ImageRemoveRequest) Reset()      { *m = ImageRemoveRequest{} }
 func (*ImageRemoveRequest) ProtoMessage() {}
 func (*ImageRemoveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{9}
+	return fileDescriptor_51c65cb1807988f9, []int{7}
 }
 func (m *ImageRemoveRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -544,7 +543,18 @@ func (m *ImageRemoveRequest) GetImage() *v1alpha2.ImageSpec {
 	return nil
 }
 
+func (m *ImageRemoveRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type ImageRemoveResponse struct {
+	// References removed from the image.
+	Untagged []string `protobuf:"bytes,1,rep,name=untagged,proto3" json:"untagged,omitempty"`
+	// IDs of the removed images.
+	Deleted              []string `protobuf:"bytes,2,rep,name=deleted,proto3" json:"deleted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -552,7 +562,7 @@ type ImageRemoveResponse struct {
 func (m *ImageRemoveResponse) Reset()      { *m = ImageRemoveResponse{} }
 func (*ImageRemoveResponse) ProtoMessage() {}
 func (*ImageRemoveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{10}
+	return fileDescriptor_51c65cb1807988f9, []int{8}
 }
 func (m *ImageRemoveResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -581,6 +591,20 @@ func (m *ImageRemoveResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ImageRemoveResponse proto.InternalMessageInfo
 
+func (m *ImageRemoveResponse) GetUntagged() []string {
+	if m != nil {
+		return m.Untagged
+	}
+	return nil
+}
+
+func (m *ImageRemoveResponse) GetDeleted() []string {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
 type ImageStatusRequest struct {
 	// Spec of the image.
 	Image                *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
@@ -591,7 +615,7 @@ type ImageStatusRequest struct {
 func (m *ImageStatusRequest) Reset()      { *m = ImageStatusRequest{} }
 func (*ImageStatusRequest) ProtoMessage() {}
 func (*ImageStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{11}
+	return fileDescriptor_51c65cb1807988f9, []int{9}
 }
 func (m *ImageStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -629,15 +653,18 @@ func (m *ImageStatusRequest) GetImage() *v1alpha2.ImageSpec {
 
 type ImageStatusResponse struct {
 	// Status of the image.
-	Image                *v1alpha2.Image `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Image *v1alpha2.Image `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Repositories, as registry/repository, that the content of the image was pulled from or pushed to, whose blobs
+	// pushes may mount.
+	Sources              []string `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ImageStatusResponse) Reset()      { *m = ImageStatusResponse{} }
 func (*ImageStatusResponse) ProtoMessage() {}
 func (*ImageStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{12}
+	return fileDescriptor_51c65cb1807988f9, []int{10}
 }
 func (m *ImageStatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -673,18 +700,29 @@ func (m *ImageStatusResponse) GetImage() *v1alpha2.Image {
 	return nil
 }
 
+func (m *ImageStatusResponse) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
 type ImageTagRequest struct {
 	// Spec of the image to remove.
-	Image                *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Tags                 []string            `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Image *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Tags  []string            `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Namespace of the image, defaults to the image namespace of the agent (k8s.io).
+	FromNamespace string `protobuf:"bytes,3,opt,name=from_namespace,json=fromNamespace,proto3" json:"from_namespace,omitempty"`
+	// Namespace of the tags, defaults to the image namespace of the agent (k8s.io). The content of the image is copied when it differs.
+	ToNamespace          string   `protobuf:"bytes,4,opt,name=to_namespace,json=toNamespace,proto3" json:"to_namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ImageTagRequest) Reset()      { *m = ImageTagRequest{} }
 func (*ImageTagRequest) ProtoMessage() {}
 func (*ImageTagRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{13}
+	return fileDescriptor_51c65cb1807988f9, []int{11}
 }
 func (m *ImageTagRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -727,6 +765,20 @@ func (m *ImageTagRequest) GetTags() []string {
 	return nil
 }
 
+func (m *ImageTagRequest) GetFromNamespace() string {
+	if m != nil {
+		return m.FromNamespace
+	}
+	return ""
+}
+
+func (m *ImageTagRequest) GetToNamespace() string {
+	if m != nil {
+		return m.ToNamespace
+	}
+	return ""
+}
+
 type ImageTagResponse struct {
 	// Status of the image.
 	Image                *v1alpha2.Image `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
@@ -737,7 +789,7 @@ type ImageTagResponse struct {
 func (m *ImageTagResponse) Reset()      { *m = ImageTagResponse{} }
 func (*ImageTagResponse) ProtoMessage() {}
 func (*ImageTagResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51c65cb1807988f9, []int{14}
+	return fileDescriptor_51c65cb1807988f9, []int{12}
 }
 func (m *ImageTagResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -773,1488 +825,7394 @@ func (m *ImageTagResponse) GetImage() *v1alpha2.Image {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ImageListRequest)(nil), "k3c.services.images.v1alpha1.ImageListRequest")
-	proto.RegisterType((*ImageListResponse)(nil), "k3c.services.images.v1alpha1.ImageListResponse")
-	proto.RegisterType((*ImagePullRequest)(nil), "k3c.services.images.v1alpha1.ImagePullRequest")
-	proto.RegisterType((*ImagePullResponse)(nil), "k3c.services.images.v1alpha1.ImagePullResponse")
-	proto.RegisterType((*ImagePushRequest)(nil), "k3c.services.images.v1alpha1.ImagePushRequest")
-	proto.RegisterType((*ImagePushResponse)(nil), "k3c.services.images.v1alpha1.ImagePushResponse")
-	proto.RegisterType((*ImageProgressRequest)(nil), "k3c.services.images.v1alpha1.ImageProgressRequest")
-	proto.RegisterType((*ImageProgressResponse)(nil), "k3c.services.images.v1alpha1.ImageProgressResponse")
-	proto.RegisterType((*ImageStatus)(nil), "k3c.services.images.v1alpha1.ImageStatus")
-	proto.RegisterType((*ImageRemoveRequest)(nil), "k3c.services.images.v1alpha1.ImageRemoveRequest")
-	proto.RegisterType((*ImageRemoveResponse)(nil), "k3c.services.images.v1alpha1.ImageRemoveResponse")
-	proto.RegisterType((*ImageStatusRequest)(nil), "k3c.services.images.v1alpha1.ImageStatusRequest")
-	proto.RegisterType((*ImageStatusResponse)(nil), "k3c.services.images.v1alpha1.ImageStatusResponse")
-	proto.RegisterType((*ImageTagRequest)(nil), "k3c.services.images.v1alpha1.ImageTagRequest")
-	proto.RegisterType((*ImageTagResponse)(nil), "k3c.services.images.v1alpha1.ImageTagResponse")
+type ImageConvertRequest struct {
+	// Spec of the image to convert.
+	Image *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Name of the converted image.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Convert docker media types to OCI.
+	Oci bool `protobuf:"varint,3,opt,name=oci,proto3" json:"oci,omitempty"`
+	// Compression of the layers: gzip, zstd or estargz, defaults to the layers as stored.
+	Compression string `protobuf:"bytes,4,opt,name=compression,proto3" json:"compression,omitempty"`
+	// Recompress layers already compressed as requested.
+	ForceCompression     bool     `protobuf:"varint,5,opt,name=force_compression,json=forceCompression,proto3" json:"force_compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("pkg/apis/services/images/v1alpha1/images.proto", fileDescriptor_51c65cb1807988f9)
+func (m *ImageConvertRequest) Reset()      { *m = ImageConvertRequest{} }
+func (*ImageConvertRequest) ProtoMessage() {}
+func (*ImageConvertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{13}
 }
-
-var fileDescriptor_51c65cb1807988f9 = []byte{
-	// 718 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0x41, 0x4f, 0xd4, 0x4e,
-	0x14, 0xdf, 0xb2, 0x4b, 0xf3, 0x67, 0xf8, 0x27, 0xe2, 0x08, 0xba, 0x59, 0xb1, 0x90, 0x9e, 0x96,
-	0xc4, 0x9d, 0xb2, 0x25, 0x26, 0x26, 0x9e, 0x16, 0x88, 0x04, 0xe3, 0xc1, 0x54, 0x0e, 0xc6, 0x8b,
-	0x0e, 0xcb, 0x6c, 0xdb, 0xb0, 0x65, 0x6a, 0x67, 0x8a, 0xf1, 0xe6, 0x47, 0xe0, 0xe8, 0x47, 0xe2,
-	0xe8, 0xd1, 0x93, 0xca, 0xf2, 0x01, 0xfc, 0x04, 0x26, 0x66, 0x66, 0x5e, 0x97, 0xae, 0x0a, 0x16,
-	0x38, 0x70, 0xeb, 0x9b, 0xf9, 0xfd, 0x7e, 0xef, 0xf7, 0x76, 0xde, 0x7b, 0x59, 0x44, 0xd2, 0xfd,
-	0xd0, 0xa3, 0x69, 0x2c, 0x3c, 0xc1, 0xb2, 0xc3, 0xb8, 0xcf, 0x84, 0x17, 0x27, 0x34, 0x64, 0xc2,
-	0x3b, 0xec, 0xd2, 0x61, 0x1a, 0xd1, 0x2e, 0xc4, 0x24, 0xcd, 0xb8, 0xe4, 0x78, 0x71, 0x7f, 0xad,
-	0x4f, 0x0a, 0x28, 0x81, 0xab, 0x02, 0xda, 0x5a, 0x0a, 0x39, 0x0f, 0x87, 0xcc, 0xd3, 0xd8, 0xdd,
-	0x7c, 0xe0, 0xc9, 0x38, 0x61, 0x42, 0xd2, 0x24, 0x35, 0xf4, 0x56, 0x27, 0x8c, 0x65, 0x94, 0xef,
-	0x92, 0x3e, 0x4f, 0xbc, 0x90, 0x87, 0xfc, 0x0c, 0xa9, 0x22, 0x1d, 0xe8, 0x2f, 0x80, 0xfb, 0xfb,
-	0x8f, 0x05, 0x89, 0xb9, 0xd7, 0xcf, 0xe2, 0x0e, 0x4d, 0x63, 0x6f, 0x6c, 0x36, 0xcb, 0x0f, 0x94,
-	0x74, 0x61, 0xd2, 0x57, 0xa7, 0x86, 0xe3, 0x6e, 0xa3, 0xb9, 0x6d, 0x65, 0xeb, 0x79, 0x2c, 0x64,
-	0xc0, 0xde, 0xe5, 0x4c, 0x48, 0xfc, 0x08, 0xd9, 0x83, 0x78, 0x28, 0x59, 0xd6, 0xb4, 0x96, 0xad,
-	0xf6, 0xac, 0xff, 0x80, 0x80, 0x40, 0x61, 0xdd, 0x27, 0x9a, 0xf3, 0x54, 0x83, 0x02, 0x00, 0xbb,
-	0x9b, 0xe8, 0x76, 0x49, 0x4a, 0xa4, 0xfc, 0x40, 0x30, 0xec, 0x21, 0xdb, 0x94, 0xdd, 0xb4, 0x96,
-	0xeb, 0xed, 0x59, 0xff, 0xde, 0x39, 0x5a, 0x01, 0xc0, 0xdc, 0xf7, 0x60, 0xe8, 0x45, 0x3e, 0x1c,
-	0x16, 0x86, 0xba, 0x68, 0x5a, 0xdf, 0x82, 0x9f, 0xfb, 0xe7, 0x68, 0xbc, 0x4c, 0x59, 0x3f, 0x30,
-	0x48, 0xbc, 0x8a, 0x1a, 0x34, 0x97, 0x51, 0x73, 0x4a, 0x33, 0x16, 0xff, 0x64, 0xf4, 0x72, 0x19,
-	0x6d, 0xf0, 0x83, 0x41, 0x1c, 0x06, 0x1a, 0xe9, 0xae, 0x80, 0x7d, 0x93, 0x18, 0xec, 0xcf, 0x97,
-	0x33, 0xcf, 0x80, 0x78, 0xc9, 0xa3, 0x88, 0x6e, 0xc8, 0xa3, 0x4a, 0x7c, 0xa1, 0xc7, 0x87, 0x68,
-	0xde, 0x40, 0x33, 0x1e, 0x66, 0x4c, 0x88, 0xc2, 0xe7, 0xdf, 0xd1, 0x6f, 0xd1, 0xc2, 0x6f, 0x68,
-	0x10, 0xdf, 0x42, 0xb6, 0x90, 0x54, 0xe6, 0xc5, 0xfb, 0xad, 0x90, 0x8b, 0x5a, 0x1a, 0x6a, 0xd4,
-	0x84, 0xf5, 0xc6, 0xf1, 0xd7, 0xa5, 0x5a, 0x00, 0x74, 0xf7, 0x87, 0x85, 0x66, 0x4b, 0xb7, 0x78,
-	0x0e, 0xd5, 0x33, 0x36, 0x00, 0x17, 0xea, 0x13, 0xdf, 0x1d, 0xa7, 0x9a, 0xd2, 0x87, 0x10, 0xa9,
-	0x73, 0x3e, 0x18, 0x08, 0x26, 0x9b, 0xf5, 0x65, 0xab, 0x5d, 0x0f, 0x20, 0x52, 0x95, 0x48, 0x2e,
-	0xe9, 0xb0, 0xd9, 0xd0, 0xc7, 0x26, 0xc0, 0x1b, 0x08, 0x09, 0x49, 0x33, 0xc9, 0xf6, 0xde, 0x50,
-	0xd9, 0x9c, 0xd6, 0x3f, 0x6d, 0x8b, 0x98, 0x49, 0x23, 0xc5, 0xfc, 0x90, 0x9d, 0x62, 0xd2, 0xd6,
-	0xff, 0x53, 0x2e, 0x8f, 0xbe, 0x2d, 0x59, 0xc1, 0x0c, 0xf0, 0x7a, 0x52, 0x89, 0xe4, 0xe9, 0x1e,
-	0x05, 0x11, 0xfb, 0x32, 0x22, 0xc0, 0xeb, 0x49, 0x77, 0x0b, 0x61, 0xd3, 0xda, 0x2c, 0xe1, 0x87,
-	0xec, 0xea, 0x7d, 0xe2, 0x2e, 0xa0, 0x3b, 0x13, 0x42, 0xe6, 0x69, 0xc6, 0xfa, 0xe6, 0x07, 0xbd,
-	0x86, 0xfe, 0x26, 0xe8, 0x17, 0x42, 0xf0, 0xf4, 0x9d, 0x49, 0xa5, 0x73, 0x27, 0x17, 0x54, 0x5e,
-	0xa1, 0x5b, 0x3a, 0xde, 0xa1, 0xe1, 0x35, 0x66, 0x02, 0xa3, 0x86, 0xa4, 0xa1, 0x6a, 0x81, 0x7a,
-	0x7b, 0x26, 0xd0, 0xdf, 0x6e, 0x0f, 0xc6, 0x4d, 0x2b, 0x5f, 0xc9, 0x9c, 0xff, 0xd3, 0x46, 0xb6,
-	0x3e, 0x10, 0x38, 0x41, 0x36, 0xb4, 0xe0, 0x6a, 0xe5, 0x5e, 0x86, 0x82, 0x5a, 0xdd, 0x4b, 0x30,
-	0xc0, 0x68, 0x88, 0x1a, 0x6a, 0x21, 0x62, 0x52, 0x81, 0x5a, 0x5a, 0xc2, 0x2d, 0xaf, 0x32, 0xfe,
-	0x2c, 0x91, 0x5a, 0x5d, 0x95, 0x12, 0x95, 0x96, 0x6b, 0xa5, 0x44, 0x13, 0x3b, 0xf1, 0x03, 0xfa,
-	0x5f, 0xc5, 0xc5, 0xaa, 0xc0, 0x7e, 0x15, 0x81, 0xc9, 0x2d, 0xd4, 0x5a, 0xbb, 0x14, 0xc7, 0x24,
-	0x5e, 0xb5, 0x4c, 0x8d, 0x22, 0xaa, 0x58, 0xe3, 0x78, 0x39, 0x57, 0xac, 0xb1, 0xb4, 0x53, 0x75,
-	0x8d, 0x22, 0xba, 0x89, 0x1a, 0x13, 0x64, 0x9b, 0x41, 0xaf, 0xd4, 0x9f, 0x13, 0xcb, 0xa5, 0x52,
-	0x7f, 0x4e, 0x6e, 0x11, 0xbc, 0x87, 0xea, 0x3b, 0x34, 0xc4, 0x9d, 0x0a, 0xcc, 0xb3, 0xc9, 0x6e,
-	0x91, 0xaa, 0x70, 0x93, 0x65, 0xfd, 0xd9, 0xf1, 0x89, 0x63, 0x7d, 0x39, 0x71, 0x6a, 0x1f, 0x47,
-	0x8e, 0x75, 0x3c, 0x72, 0xac, 0xcf, 0x23, 0xc7, 0xfa, 0x3e, 0x72, 0xac, 0xa3, 0x53, 0xa7, 0xf6,
-	0xe9, 0xd4, 0xa9, 0xbd, 0x6e, 0xff, 0xf3, 0xaf, 0xd5, 0x13, 0x13, 0xef, 0xda, 0x7a, 0x01, 0xaf,
-	0xfd, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x96, 0x98, 0xec, 0xc4, 0x8d, 0x09, 0x00, 0x00,
+func (m *ImageConvertRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// ImagesClient is the client API for Images service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ImagesClient interface {
-	// Status of an image
-	Status(ctx context.Context, in *ImageStatusRequest, opts ...grpc.CallOption) (*ImageStatusResponse, error)
-	// List images
-	List(ctx context.Context, in *ImageListRequest, opts ...grpc.CallOption) (*ImageListResponse, error)
-	// Pull an image
-	Pull(ctx context.Context, in *ImagePullRequest, opts ...grpc.CallOption) (*ImagePullResponse, error)
-	PullProgress(ctx context.Context, in *ImageProgressRequest, opts ...grpc.CallOption) (Images_PullProgressClient, error)
-	// Push an image
-	Push(ctx context.Context, in *ImagePushRequest, opts ...grpc.CallOption) (*ImagePushResponse, error)
-	PushProgress(ctx context.Context, in *ImageProgressRequest, opts ...grpc.CallOption) (Images_PushProgressClient, error)
-	// Remove an image
-	Remove(ctx context.Context, in *ImageRemoveRequest, opts ...grpc.CallOption) (*ImageRemoveResponse, error)
-	// Tag an image
-	Tag(ctx context.Context, in *ImageTagRequest, opts ...grpc.CallOption) (*ImageTagResponse, error)
+func (m *ImageConvertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImageConvertRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-type imagesClient struct {
-	cc *grpc.ClientConn
+func (m *ImageConvertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageConvertRequest.Merge(m, src)
 }
-
-func NewImagesClient(cc *grpc.ClientConn) ImagesClient {
-	return &imagesClient{cc}
+func (m *ImageConvertRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImageConvertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageConvertRequest.DiscardUnknown(m)
 }
 
-func (c *imagesClient) Status(ctx context.Context, in *ImageStatusRequest, opts ...grpc.CallOption) (*ImageStatusResponse, error) {
-	out := new(ImageStatusResponse)
-	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Status", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ImageConvertRequest proto.InternalMessageInfo
+
+func (m *ImageConvertRequest) GetImage() *v1alpha2.ImageSpec {
+	if m != nil {
+		return m.Image
 	}
-	return out, nil
+	return nil
 }
 
-func (c *imagesClient) List(ctx context.Context, in *ImageListRequest, opts ...grpc.CallOption) (*ImageListResponse, error) {
-	out := new(ImageListResponse)
-	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/List", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ImageConvertRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
 	}
-	return out, nil
+	return ""
 }
 
-func (c *imagesClient) Pull(ctx context.Context, in *ImagePullRequest, opts ...grpc.CallOption) (*ImagePullResponse, error) {
-	out := new(ImagePullResponse)
-	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Pull", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ImageConvertRequest) GetOci() bool {
+	if m != nil {
+		return m.Oci
 	}
-	return out, nil
+	return false
 }
 
-func (c *imagesClient) PullProgress(ctx context.Context, in *ImageProgressRequest, opts ...grpc.CallOption) (Images_PullProgressClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Images_serviceDesc.Streams[0], "/k3c.services.images.v1alpha1.Images/PullProgress", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &imagesPullProgressClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ImageConvertRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
 	}
-	return x, nil
+	return ""
 }
 
-type Images_PullProgressClient interface {
-	Recv() (*ImageProgressResponse, error)
-	grpc.ClientStream
+func (m *ImageConvertRequest) GetForceCompression() bool {
+	if m != nil {
+		return m.ForceCompression
+	}
+	return false
 }
 
-type imagesPullProgressClient struct {
-	grpc.ClientStream
+type ImageConvertResponse struct {
+	// Name of the converted image.
+	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *imagesPullProgressClient) Recv() (*ImageProgressResponse, error) {
-	m := new(ImageProgressResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *ImageConvertResponse) Reset()      { *m = ImageConvertResponse{} }
+func (*ImageConvertResponse) ProtoMessage() {}
+func (*ImageConvertResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{14}
 }
-
-func (c *imagesClient) Push(ctx context.Context, in *ImagePushRequest, opts ...grpc.CallOption) (*ImagePushResponse, error) {
-	out := new(ImagePushResponse)
-	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Push", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ImageConvertResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImageConvertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImageConvertResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *ImageConvertResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageConvertResponse.Merge(m, src)
+}
+func (m *ImageConvertResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImageConvertResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageConvertResponse.DiscardUnknown(m)
 }
 
-func (c *imagesClient) PushProgress(ctx context.Context, in *ImageProgressRequest, opts ...grpc.CallOption) (Images_PushProgressClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Images_serviceDesc.Streams[1], "/k3c.services.images.v1alpha1.Images/PushProgress", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &imagesPushProgressClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+var xxx_messageInfo_ImageConvertResponse proto.InternalMessageInfo
+
+func (m *ImageConvertResponse) GetImage() string {
+	if m != nil {
+		return m.Image
 	}
-	return x, nil
+	return ""
 }
 
-type Images_PushProgressClient interface {
-	Recv() (*ImageProgressResponse, error)
-	grpc.ClientStream
+type ImageCopyRequest struct {
+	// Spec of the image to copy.
+	Image *v1alpha2.ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Name of the copied image, defaults to the name of the image.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Namespace of the image, defaults to the image namespace of the agent (k8s.io).
+	FromNamespace string `protobuf:"bytes,3,opt,name=from_namespace,json=fromNamespace,proto3" json:"from_namespace,omitempty"`
+	// Namespace of the copied image, defaults to the image namespace of the agent (k8s.io).
+	ToNamespace          string   `protobuf:"bytes,4,opt,name=to_namespace,json=toNamespace,proto3" json:"to_namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type imagesPushProgressClient struct {
-	grpc.ClientStream
+func (m *ImageCopyRequest) Reset()      { *m = ImageCopyRequest{} }
+func (*ImageCopyRequest) ProtoMessage() {}
+func (*ImageCopyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{15}
 }
-
-func (x *imagesPushProgressClient) Recv() (*ImageProgressResponse, error) {
-	m := new(ImageProgressResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ImageCopyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImageCopyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImageCopyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return m, nil
+}
+func (m *ImageCopyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageCopyRequest.Merge(m, src)
+}
+func (m *ImageCopyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImageCopyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageCopyRequest.DiscardUnknown(m)
 }
 
-func (c *imagesClient) Remove(ctx context.Context, in *ImageRemoveRequest, opts ...grpc.CallOption) (*ImageRemoveResponse, error) {
-	out := new(ImageRemoveResponse)
-	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Remove", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ImageCopyRequest proto.InternalMessageInfo
+
+func (m *ImageCopyRequest) GetImage() *v1alpha2.ImageSpec {
+	if m != nil {
+		return m.Image
 	}
-	return out, nil
+	return nil
 }
 
-func (c *imagesClient) Tag(ctx context.Context, in *ImageTagRequest, opts ...grpc.CallOption) (*ImageTagResponse, error) {
-	out := new(ImageTagResponse)
-	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Tag", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ImageCopyRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
 	}
-	return out, nil
+	return ""
 }
 
-// ImagesServer is the server API for Images service.
-type ImagesServer interface {
-	// Status of an image
-	Status(context.Context, *ImageStatusRequest) (*ImageStatusResponse, error)
-	// List images
-	List(context.Context, *ImageListRequest) (*ImageListResponse, error)
-	// Pull an image
-	Pull(context.Context, *ImagePullRequest) (*ImagePullResponse, error)
-	PullProgress(*ImageProgressRequest, Images_PullProgressServer) error
-	// Push an image
-	Push(context.Context, *ImagePushRequest) (*ImagePushResponse, error)
-	PushProgress(*ImageProgressRequest, Images_PushProgressServer) error
-	// Remove an image
-	Remove(context.Context, *ImageRemoveRequest) (*ImageRemoveResponse, error)
-	// Tag an image
-	Tag(context.Context, *ImageTagRequest) (*ImageTagResponse, error)
+func (m *ImageCopyRequest) GetFromNamespace() string {
+	if m != nil {
+		return m.FromNamespace
+	}
+	return ""
 }
 
-// UnimplementedImagesServer can be embedded to have forward compatible implementations.
-type UnimplementedImagesServer struct {
+func (m *ImageCopyRequest) GetToNamespace() string {
+	if m != nil {
+		return m.ToNamespace
+	}
+	return ""
 }
 
-func (*UnimplementedImagesServer) Status(ctx context.Context, req *ImageStatusRequest) (*ImageStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
-}
-func (*UnimplementedImagesServer) List(ctx context.Context, req *ImageListRequest) (*ImageListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+type ImageCopyResponse struct {
+	// Name of the copied image.
+	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedImagesServer) Pull(ctx context.Context, req *ImagePullRequest) (*ImagePullResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Pull not implemented")
+
+func (m *ImageCopyResponse) Reset()      { *m = ImageCopyResponse{} }
+func (*ImageCopyResponse) ProtoMessage() {}
+func (*ImageCopyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{16}
 }
-func (*UnimplementedImagesServer) PullProgress(req *ImageProgressRequest, srv Images_PullProgressServer) error {
-	return status.Errorf(codes.Unimplemented, "method PullProgress not implemented")
+func (m *ImageCopyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedImagesServer) Push(ctx context.Context, req *ImagePushRequest) (*ImagePushResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
+func (m *ImageCopyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImageCopyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedImagesServer) PushProgress(req *ImageProgressRequest, srv Images_PushProgressServer) error {
-	return status.Errorf(codes.Unimplemented, "method PushProgress not implemented")
+func (m *ImageCopyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageCopyResponse.Merge(m, src)
 }
-func (*UnimplementedImagesServer) Remove(ctx context.Context, req *ImageRemoveRequest) (*ImageRemoveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+func (m *ImageCopyResponse) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedImagesServer) Tag(ctx context.Context, req *ImageTagRequest) (*ImageTagResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Tag not implemented")
+func (m *ImageCopyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageCopyResponse.DiscardUnknown(m)
 }
 
-func RegisterImagesServer(s *grpc.Server, srv ImagesServer) {
-	s.RegisterService(&_Images_serviceDesc, srv)
-}
+var xxx_messageInfo_ImageCopyResponse proto.InternalMessageInfo
 
-func _Images_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImageStatusRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ImagesServer).Status(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/k3c.services.images.v1alpha1.Images/Status",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImagesServer).Status(ctx, req.(*ImageStatusRequest))
+func (m *ImageCopyResponse) GetImage() string {
+	if m != nil {
+		return m.Image
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Images_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImageListRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ImagesServer).List(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/k3c.services.images.v1alpha1.Images/List",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImagesServer).List(ctx, req.(*ImageListRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type ImageEventsRequest struct {
+	// Filters in key=value form, e.g. type=image or namespace=k8s.io.
+	Filters []string `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	// Replay image events since this time before streaming live events.
+	Since                *time.Time `protobuf:"bytes,2,opt,name=since,proto3,stdtime" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func _Images_Pull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImagePullRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ImagesServer).Pull(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/k3c.services.images.v1alpha1.Images/Pull",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImagesServer).Pull(ctx, req.(*ImagePullRequest))
+func (m *ImageEventsRequest) Reset()      { *m = ImageEventsRequest{} }
+func (*ImageEventsRequest) ProtoMessage() {}
+func (*ImageEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{17}
+}
+func (m *ImageEventsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImageEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImageEventsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *ImageEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageEventsRequest.Merge(m, src)
+}
+func (m *ImageEventsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImageEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageEventsRequest.DiscardUnknown(m)
 }
 
-func _Images_PullProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ImageProgressRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_ImageEventsRequest proto.InternalMessageInfo
+
+func (m *ImageEventsRequest) GetFilters() []string {
+	if m != nil {
+		return m.Filters
 	}
-	return srv.(ImagesServer).PullProgress(m, &imagesPullProgressServer{stream})
+	return nil
 }
 
-type Images_PullProgressServer interface {
-	Send(*ImageProgressResponse) error
-	grpc.ServerStream
+func (m *ImageEventsRequest) GetSince() *time.Time {
+	if m != nil {
+		return m.Since
+	}
+	return nil
 }
 
-type imagesPullProgressServer struct {
-	grpc.ServerStream
+type ImageEventsResponse struct {
+	Timestamp time.Time `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// Containerd namespace the event occurred in.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Type of the event: image, content or build.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Action of the event, e.g. create, update, delete, ingest, commit or complete.
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// Name of the image or content ref/digest.
+	Name                 string            `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (x *imagesPullProgressServer) Send(m *ImageProgressResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *ImageEventsResponse) Reset()      { *m = ImageEventsResponse{} }
+func (*ImageEventsResponse) ProtoMessage() {}
+func (*ImageEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{18}
 }
-
-func _Images_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImagePushRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ImageEventsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImageEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImageEventsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if interceptor == nil {
-		return srv.(ImagesServer).Push(ctx, in)
+}
+func (m *ImageEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageEventsResponse.Merge(m, src)
+}
+func (m *ImageEventsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImageEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageEventsResponse proto.InternalMessageInfo
+
+func (m *ImageEventsResponse) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/k3c.services.images.v1alpha1.Images/Push",
+	return time.Time{}
+}
+
+func (m *ImageEventsResponse) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImagesServer).Push(ctx, req.(*ImagePushRequest))
+	return ""
+}
+
+func (m *ImageEventsResponse) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Images_PushProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ImageProgressRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *ImageEventsResponse) GetAction() string {
+	if m != nil {
+		return m.Action
 	}
-	return srv.(ImagesServer).PushProgress(m, &imagesPushProgressServer{stream})
+	return ""
 }
 
-type Images_PushProgressServer interface {
-	Send(*ImageProgressResponse) error
-	grpc.ServerStream
+func (m *ImageEventsResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type imagesPushProgressServer struct {
-	grpc.ServerStream
+func (m *ImageEventsResponse) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
 }
 
-func (x *imagesPushProgressServer) Send(m *ImageProgressResponse) error {
-	return x.ServerStream.SendMsg(m)
+type VersionRequest struct {
+	// Version of the client.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Git commit of the client.
+	GitCommit string `protobuf:"bytes,2,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	// Version of the api implemented by the client.
+	ApiVersion uint32 `protobuf:"varint,3,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// Capabilities of the client.
+	Capabilities         []string `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _Images_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImageRemoveRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ImagesServer).Remove(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/k3c.services.images.v1alpha1.Images/Remove",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImagesServer).Remove(ctx, req.(*ImageRemoveRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *VersionRequest) Reset()      { *m = VersionRequest{} }
+func (*VersionRequest) ProtoMessage() {}
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{19}
 }
-
-func _Images_Tag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ImageTagRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ImagesServer).Tag(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/k3c.services.images.v1alpha1.Images/Tag",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImagesServer).Tag(ctx, req.(*ImageTagRequest))
+func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-var _Images_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "k3c.services.images.v1alpha1.Images",
-	HandlerType: (*ImagesServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Status",
-			Handler:    _Images_Status_Handler,
-		},
-		{
-			MethodName: "List",
-			Handler:    _Images_List_Handler,
-		},
-		{
-			MethodName: "Pull",
-			Handler:    _Images_Pull_Handler,
-		},
-		{
-			MethodName: "Push",
-			Handler:    _Images_Push_Handler,
-		},
-		{
-			MethodName: "Remove",
-			Handler:    _Images_Remove_Handler,
-		},
-		{
-			MethodName: "Tag",
-			Handler:    _Images_Tag_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "PullProgress",
-			Handler:       _Images_PullProgress_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "PushProgress",
-			Handler:       _Images_PushProgress_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "pkg/apis/services/images/v1alpha1/images.proto",
+func (m *VersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionRequest.Merge(m, src)
+}
+func (m *VersionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *VersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionRequest.DiscardUnknown(m)
 }
 
-func (m *ImageListRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_VersionRequest proto.InternalMessageInfo
+
+func (m *VersionRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ImageListRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *VersionRequest) GetGitCommit() string {
+	if m != nil {
+		return m.GitCommit
+	}
+	return ""
 }
 
-func (m *ImageListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Filter != nil {
-		{
-			size, err := m.Filter.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *VersionRequest) GetApiVersion() uint32 {
+	if m != nil {
+		return m.ApiVersion
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ImageListResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *VersionRequest) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImageListResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type VersionResponse struct {
+	// Version of the agent.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Git commit of the agent.
+	GitCommit string `protobuf:"bytes,2,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	// Version of the api implemented by the agent.
+	ApiVersion uint32 `protobuf:"varint,3,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// Oldest version of the api of clients that the agent is compatible with.
+	MinApiVersion uint32 `protobuf:"varint,4,opt,name=min_api_version,json=minApiVersion,proto3" json:"min_api_version,omitempty"`
+	// Capabilities of the agent, e.g. info or authorization.
+	Capabilities         []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImageListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Images) > 0 {
-		for iNdEx := len(m.Images) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Images[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintImages(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *VersionResponse) Reset()      { *m = VersionResponse{} }
+func (*VersionResponse) ProtoMessage() {}
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{20}
+}
+func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *VersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionResponse.Merge(m, src)
+}
+func (m *VersionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionResponse.DiscardUnknown(m)
 }
 
-func (m *ImagePullRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_VersionResponse proto.InternalMessageInfo
+
+func (m *VersionResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ImagePullRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *VersionResponse) GetGitCommit() string {
+	if m != nil {
+		return m.GitCommit
+	}
+	return ""
 }
 
-func (m *ImagePullRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Auth != nil {
-		{
-			size, err := m.Auth.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (m *VersionResponse) GetApiVersion() uint32 {
+	if m != nil {
+		return m.ApiVersion
 	}
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *VersionResponse) GetMinApiVersion() uint32 {
+	if m != nil {
+		return m.MinApiVersion
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ImagePullResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *VersionResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImagePullResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LoginRequest struct {
+	// Registry to authenticate against, e.g. docker.io or registry.local:5000.
+	Server string `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	// Credentials to verify.
+	Auth                 *v1alpha2.AuthConfig `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ImagePullResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Image) > 0 {
-		i -= len(m.Image)
-		copy(dAtA[i:], m.Image)
-		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
-		i--
-		dAtA[i] = 0xa
+func (m *LoginRequest) Reset()      { *m = LoginRequest{} }
+func (*LoginRequest) ProtoMessage() {}
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{21}
+}
+func (m *LoginRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LoginRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LoginRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *LoginRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoginRequest.Merge(m, src)
+}
+func (m *LoginRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LoginRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoginRequest.DiscardUnknown(m)
 }
 
-func (m *ImagePushRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LoginRequest proto.InternalMessageInfo
+
+func (m *LoginRequest) GetServer() string {
+	if m != nil {
+		return m.Server
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ImagePushRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LoginRequest) GetAuth() *v1alpha2.AuthConfig {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
 }
 
-func (m *ImagePushRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Auth != nil {
-		{
-			size, err := m.Auth.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
+type LoginResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoginResponse) Reset()      { *m = LoginResponse{} }
+func (*LoginResponse) ProtoMessage() {}
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{22}
+}
+func (m *LoginResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LoginResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LoginResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
+}
+func (m *LoginResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoginResponse.Merge(m, src)
+}
+func (m *LoginResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LoginResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoginResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoginResponse proto.InternalMessageInfo
+
+type InfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InfoRequest) Reset()      { *m = InfoRequest{} }
+func (*InfoRequest) ProtoMessage() {}
+func (*InfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{23}
+}
+func (m *InfoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InfoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *InfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InfoRequest.Merge(m, src)
+}
+func (m *InfoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InfoRequest proto.InternalMessageInfo
+
+type InfoResponse struct {
+	// Version of the agent.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Git commit of the agent.
+	GitCommit string `protobuf:"bytes,2,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	// Name of the node the agent runs on.
+	Node       string          `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Buildkit   *BuildkitInfo   `protobuf:"bytes,4,opt,name=buildkit,proto3" json:"buildkit,omitempty"`
+	Containerd *ContainerdInfo `protobuf:"bytes,5,opt,name=containerd,proto3" json:"containerd,omitempty"`
+	Runtime    *RuntimeInfo    `protobuf:"bytes,6,opt,name=runtime,proto3" json:"runtime,omitempty"`
+	Images     *ImagesInfo     `protobuf:"bytes,7,opt,name=images,proto3" json:"images,omitempty"`
+	// Health of the backends by health service, i.e. containerd, cri and buildkit: SERVING or the reason they are not.
+	Health               map[string]string `protobuf:"bytes,8,rep,name=health,proto3" json:"health,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *ImagePushResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *InfoResponse) Reset()      { *m = InfoResponse{} }
+func (*InfoResponse) ProtoMessage() {}
+func (*InfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{24}
+}
+func (m *InfoResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InfoResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *ImagePushResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *InfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InfoResponse.Merge(m, src)
+}
+func (m *InfoResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *InfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InfoResponse.DiscardUnknown(m)
 }
 
-func (m *ImagePushResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Image) > 0 {
-		i -= len(m.Image)
-		copy(dAtA[i:], m.Image)
-		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_InfoResponse proto.InternalMessageInfo
+
+func (m *InfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ImageProgressRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *InfoResponse) GetGitCommit() string {
+	if m != nil {
+		return m.GitCommit
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ImageProgressRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *InfoResponse) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
 }
 
-func (m *ImageProgressRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Image) > 0 {
-		i -= len(m.Image)
-		copy(dAtA[i:], m.Image)
-		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
-		i--
-		dAtA[i] = 0xa
+func (m *InfoResponse) GetBuildkit() *BuildkitInfo {
+	if m != nil {
+		return m.Buildkit
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *ImageProgressResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *InfoResponse) GetContainerd() *ContainerdInfo {
+	if m != nil {
+		return m.Containerd
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImageProgressResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *InfoResponse) GetRuntime() *RuntimeInfo {
+	if m != nil {
+		return m.Runtime
+	}
+	return nil
 }
 
-func (m *ImageProgressResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Status) > 0 {
-		for iNdEx := len(m.Status) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Status[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintImages(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (m *InfoResponse) GetImages() *ImagesInfo {
+	if m != nil {
+		return m.Images
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *ImageStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *InfoResponse) GetHealth() map[string]string {
+	if m != nil {
+		return m.Health
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImageStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type BuildkitInfo struct {
+	// Workers of buildkit.
+	Workers []*BuildkitWorker `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
+	// Version of buildkitd, i.e. the tag of its image, as its api does not report it.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Image of the buildkitd container, with the digest it runs.
+	Image                string   `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImageStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt):])
-	if err6 != nil {
-		return 0, err6
-	}
-	i -= n6
-	i = encodeVarintImages(dAtA, i, uint64(n6))
-	i--
-	dAtA[i] = 0x32
-	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartedAt):])
-	if err7 != nil {
-		return 0, err7
-	}
-	i -= n7
-	i = encodeVarintImages(dAtA, i, uint64(n7))
-	i--
-	dAtA[i] = 0x2a
-	if m.Total != 0 {
-		i = encodeVarintImages(dAtA, i, uint64(m.Total))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Offset != 0 {
-		i = encodeVarintImages(dAtA, i, uint64(m.Offset))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Status) > 0 {
-		i -= len(m.Status)
-		copy(dAtA[i:], m.Status)
-		i = encodeVarintImages(dAtA, i, uint64(len(m.Status)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Ref) > 0 {
-		i -= len(m.Ref)
-		copy(dAtA[i:], m.Ref)
-		i = encodeVarintImages(dAtA, i, uint64(len(m.Ref)))
-		i--
-		dAtA[i] = 0xa
+func (m *BuildkitInfo) Reset()      { *m = BuildkitInfo{} }
+func (*BuildkitInfo) ProtoMessage() {}
+func (*BuildkitInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{25}
+}
+func (m *BuildkitInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BuildkitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BuildkitInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *BuildkitInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildkitInfo.Merge(m, src)
+}
+func (m *BuildkitInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *BuildkitInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildkitInfo.DiscardUnknown(m)
 }
 
-func (m *ImageRemoveRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BuildkitInfo proto.InternalMessageInfo
+
+func (m *BuildkitInfo) GetWorkers() []*BuildkitWorker {
+	if m != nil {
+		return m.Workers
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImageRemoveRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *BuildkitInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
 }
 
-func (m *ImageRemoveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *BuildkitInfo) GetImage() string {
+	if m != nil {
+		return m.Image
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ImageRemoveResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type BuildkitWorker struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Platforms the worker builds for, e.g. linux/amd64.
+	Platforms            []string          `protobuf:"bytes,2,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *ImageRemoveResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *BuildkitWorker) Reset()      { *m = BuildkitWorker{} }
+func (*BuildkitWorker) ProtoMessage() {}
+func (*BuildkitWorker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{26}
 }
-
-func (m *ImageRemoveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *BuildkitWorker) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *ImageStatusRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BuildkitWorker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BuildkitWorker.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *BuildkitWorker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuildkitWorker.Merge(m, src)
+}
+func (m *BuildkitWorker) XXX_Size() int {
+	return m.Size()
+}
+func (m *BuildkitWorker) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuildkitWorker.DiscardUnknown(m)
 }
 
-func (m *ImageStatusRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_BuildkitWorker proto.InternalMessageInfo
+
+func (m *BuildkitWorker) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func (m *ImageStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *BuildkitWorker) GetPlatforms() []string {
+	if m != nil {
+		return m.Platforms
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *ImageStatusResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BuildkitWorker) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImageStatusResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ContainerdInfo struct {
+	Version  string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Revision string `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Namespaces of containerd.
+	Namespaces           []string `protobuf:"bytes,3,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImageStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
+func (m *ContainerdInfo) Reset()      { *m = ContainerdInfo{} }
+func (*ContainerdInfo) ProtoMessage() {}
+func (*ContainerdInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{27}
+}
+func (m *ContainerdInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContainerdInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContainerdInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *ImageTagRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ContainerdInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerdInfo.Merge(m, src)
 }
-
-func (m *ImageTagRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ContainerdInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContainerdInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerdInfo.DiscardUnknown(m)
 }
 
-func (m *ImageTagRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Tags) > 0 {
-		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Tags[iNdEx])
-			copy(dAtA[i:], m.Tags[iNdEx])
-			i = encodeVarintImages(dAtA, i, uint64(len(m.Tags[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
+var xxx_messageInfo_ContainerdInfo proto.InternalMessageInfo
+
+func (m *ContainerdInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *ContainerdInfo) GetRevision() string {
+	if m != nil {
+		return m.Revision
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ImageTagResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ContainerdInfo) GetNamespaces() []string {
+	if m != nil {
+		return m.Namespaces
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ImageTagResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RuntimeInfo struct {
+	// Name of the cri runtime.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the cri runtime.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Version of the cri api.
+	ApiVersion string `protobuf:"bytes,3,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// Conditions of the cri runtime, e.g. RuntimeReady and NetworkReady.
+	Conditions           []*v1alpha2.RuntimeCondition `protobuf:"bytes,4,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-func (m *ImageTagResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Image != nil {
-		{
-			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintImages(dAtA, i, uint64(size))
+func (m *RuntimeInfo) Reset()      { *m = RuntimeInfo{} }
+func (*RuntimeInfo) ProtoMessage() {}
+func (*RuntimeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{28}
+}
+func (m *RuntimeInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RuntimeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RuntimeInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RuntimeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuntimeInfo.Merge(m, src)
+}
+func (m *RuntimeInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *RuntimeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuntimeInfo.DiscardUnknown(m)
 }
 
-func encodeVarintImages(dAtA []byte, offset int, v uint64) int {
-	offset -= sovImages(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_RuntimeInfo proto.InternalMessageInfo
+
+func (m *RuntimeInfo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return ""
 }
-func (m *ImageListRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Filter != nil {
-		l = m.Filter.Size()
-		n += 1 + l + sovImages(uint64(l))
+
+func (m *RuntimeInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return n
+	return ""
 }
 
-func (m *ImageListResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RuntimeInfo) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
 	}
-	var l int
-	_ = l
-	if len(m.Images) > 0 {
-		for _, e := range m.Images {
-			l = e.Size()
-			n += 1 + l + sovImages(uint64(l))
-		}
+	return ""
+}
+
+func (m *RuntimeInfo) GetConditions() []*v1alpha2.RuntimeCondition {
+	if m != nil {
+		return m.Conditions
 	}
-	return n
+	return nil
 }
 
-func (m *ImagePullRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
-	}
-	if m.Auth != nil {
-		l = m.Auth.Size()
-		n += 1 + l + sovImages(uint64(l))
-	}
-	return n
+type ImagesInfo struct {
+	// Namespace of the images, i.e. that of the cri.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Number of images.
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Total size of the images.
+	Size_ uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Usage of the filesystems storing the images.
+	Filesystems []*v1alpha2.FilesystemUsage `protobuf:"bytes,4,rep,name=filesystems,proto3" json:"filesystems,omitempty"`
+	// Size of the content stored by containerd, by namespace.
+	ContentSizes         map[string]int64 `protobuf:"bytes,5,rep,name=content_sizes,json=contentSizes,proto3" json:"content_sizes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *ImagePullResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovImages(uint64(l))
+func (m *ImagesInfo) Reset()      { *m = ImagesInfo{} }
+func (*ImagesInfo) ProtoMessage() {}
+func (*ImagesInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51c65cb1807988f9, []int{29}
+}
+func (m *ImagesInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImagesInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImagesInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ImagesInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImagesInfo.Merge(m, src)
+}
+func (m *ImagesInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImagesInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImagesInfo.DiscardUnknown(m)
 }
 
-func (m *ImagePushRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
-	}
-	if m.Auth != nil {
-		l = m.Auth.Size()
-		n += 1 + l + sovImages(uint64(l))
+var xxx_messageInfo_ImagesInfo proto.InternalMessageInfo
+
+func (m *ImagesInfo) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	return n
+	return ""
 }
 
-func (m *ImagePushResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovImages(uint64(l))
+func (m *ImagesInfo) GetCount() int64 {
+	if m != nil {
+		return m.Count
 	}
-	return n
+	return 0
 }
 
-func (m *ImageProgressRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovImages(uint64(l))
+func (m *ImagesInfo) GetSize_() uint64 {
+	if m != nil {
+		return m.Size_
 	}
-	return n
+	return 0
 }
 
-func (m *ImageProgressResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Status) > 0 {
-		for _, e := range m.Status {
-			l = e.Size()
-			n += 1 + l + sovImages(uint64(l))
-		}
+func (m *ImagesInfo) GetFilesystems() []*v1alpha2.FilesystemUsage {
+	if m != nil {
+		return m.Filesystems
 	}
-	return n
+	return nil
 }
 
-func (m *ImageStatus) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Ref)
-	if l > 0 {
-		n += 1 + l + sovImages(uint64(l))
-	}
-	l = len(m.Status)
-	if l > 0 {
-		n += 1 + l + sovImages(uint64(l))
-	}
-	if m.Offset != 0 {
-		n += 1 + sovImages(uint64(m.Offset))
-	}
-	if m.Total != 0 {
-		n += 1 + sovImages(uint64(m.Total))
+func (m *ImagesInfo) GetContentSizes() map[string]int64 {
+	if m != nil {
+		return m.ContentSizes
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartedAt)
-	n += 1 + l + sovImages(uint64(l))
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)
-	n += 1 + l + sovImages(uint64(l))
-	return n
+	return nil
 }
 
-func (m *ImageRemoveRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
-	}
-	return n
+func init() {
+	proto.RegisterType((*ImageListRequest)(nil), "k3c.services.images.v1alpha1.ImageListRequest")
+	proto.RegisterType((*ImageListResponse)(nil), "k3c.services.images.v1alpha1.ImageListResponse")
+	proto.RegisterType((*ImagePullRequest)(nil), "k3c.services.images.v1alpha1.ImagePullRequest")
+	proto.RegisterType((*ImagePullResponse)(nil), "k3c.services.images.v1alpha1.ImagePullResponse")
+	proto.RegisterType((*ImagePushRequest)(nil), "k3c.services.images.v1alpha1.ImagePushRequest")
+	proto.RegisterType((*ImagePushResponse)(nil), "k3c.services.images.v1alpha1.ImagePushResponse")
+	proto.RegisterType((*ImageStatus)(nil), "k3c.services.images.v1alpha1.ImageStatus")
+	proto.RegisterType((*ImageRemoveRequest)(nil), "k3c.services.images.v1alpha1.ImageRemoveRequest")
+	proto.RegisterType((*ImageRemoveResponse)(nil), "k3c.services.images.v1alpha1.ImageRemoveResponse")
+	proto.RegisterType((*ImageStatusRequest)(nil), "k3c.services.images.v1alpha1.ImageStatusRequest")
+	proto.RegisterType((*ImageStatusResponse)(nil), "k3c.services.images.v1alpha1.ImageStatusResponse")
+	proto.RegisterType((*ImageTagRequest)(nil), "k3c.services.images.v1alpha1.ImageTagRequest")
+	proto.RegisterType((*ImageTagResponse)(nil), "k3c.services.images.v1alpha1.ImageTagResponse")
+	proto.RegisterType((*ImageConvertRequest)(nil), "k3c.services.images.v1alpha1.ImageConvertRequest")
+	proto.RegisterType((*ImageConvertResponse)(nil), "k3c.services.images.v1alpha1.ImageConvertResponse")
+	proto.RegisterType((*ImageCopyRequest)(nil), "k3c.services.images.v1alpha1.ImageCopyRequest")
+	proto.RegisterType((*ImageCopyResponse)(nil), "k3c.services.images.v1alpha1.ImageCopyResponse")
+	proto.RegisterType((*ImageEventsRequest)(nil), "k3c.services.images.v1alpha1.ImageEventsRequest")
+	proto.RegisterType((*ImageEventsResponse)(nil), "k3c.services.images.v1alpha1.ImageEventsResponse")
+	proto.RegisterMapType((map[string]string)(nil), "k3c.services.images.v1alpha1.ImageEventsResponse.AttributesEntry")
+	proto.RegisterType((*VersionRequest)(nil), "k3c.services.images.v1alpha1.VersionRequest")
+	proto.RegisterType((*VersionResponse)(nil), "k3c.services.images.v1alpha1.VersionResponse")
+	proto.RegisterType((*LoginRequest)(nil), "k3c.services.images.v1alpha1.LoginRequest")
+	proto.RegisterType((*LoginResponse)(nil), "k3c.services.images.v1alpha1.LoginResponse")
+	proto.RegisterType((*InfoRequest)(nil), "k3c.services.images.v1alpha1.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "k3c.services.images.v1alpha1.InfoResponse")
+	proto.RegisterMapType((map[string]string)(nil), "k3c.services.images.v1alpha1.InfoResponse.HealthEntry")
+	proto.RegisterType((*BuildkitInfo)(nil), "k3c.services.images.v1alpha1.BuildkitInfo")
+	proto.RegisterType((*BuildkitWorker)(nil), "k3c.services.images.v1alpha1.BuildkitWorker")
+	proto.RegisterMapType((map[string]string)(nil), "k3c.services.images.v1alpha1.BuildkitWorker.LabelsEntry")
+	proto.RegisterType((*ContainerdInfo)(nil), "k3c.services.images.v1alpha1.ContainerdInfo")
+	proto.RegisterType((*RuntimeInfo)(nil), "k3c.services.images.v1alpha1.RuntimeInfo")
+	proto.RegisterType((*ImagesInfo)(nil), "k3c.services.images.v1alpha1.ImagesInfo")
+	proto.RegisterMapType((map[string]int64)(nil), "k3c.services.images.v1alpha1.ImagesInfo.ContentSizesEntry")
 }
 
-func (m *ImageRemoveResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
+func init() {
+	proto.RegisterFile("pkg/apis/services/images/v1alpha1/images.proto", fileDescriptor_51c65cb1807988f9)
 }
 
-func (m *ImageStatusRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
-	}
-	return n
+var fileDescriptor_51c65cb1807988f9 = []byte{
+	// 1725 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x18, 0x4d, 0x8f, 0x23, 0x47,
+	0x75, 0xdb, 0x5f, 0x63, 0x3f, 0xcf, 0xc7, 0x4e, 0xed, 0x2a, 0x58, 0xcd, 0xc6, 0x3b, 0x69, 0x04,
+	0xcc, 0x26, 0x99, 0xf6, 0x8e, 0x23, 0xa2, 0x25, 0x08, 0x81, 0xc7, 0x64, 0xc3, 0xc2, 0x28, 0x5a,
+	0x75, 0x96, 0x0f, 0x21, 0x05, 0x53, 0xd3, 0x2e, 0xb7, 0x4b, 0xd3, 0xee, 0x6e, 0xba, 0xcb, 0x46,
+	0xc3, 0x29, 0x12, 0x12, 0x27, 0x84, 0x72, 0xe4, 0x88, 0xb8, 0xf0, 0x13, 0x38, 0x70, 0xe2, 0xb6,
+	0x12, 0x17, 0x8e, 0x70, 0x81, 0xec, 0xe4, 0x07, 0xf0, 0x17, 0x50, 0x7d, 0xb5, 0xab, 0x33, 0x19,
+	0xbb, 0x9d, 0x49, 0xb8, 0xd5, 0x7b, 0xfd, 0xbe, 0xea, 0x7d, 0xd5, 0x7b, 0x0d, 0x6e, 0x72, 0x1e,
+	0xf4, 0x70, 0x42, 0xb3, 0x5e, 0x46, 0xd2, 0x05, 0xf5, 0x49, 0xd6, 0xa3, 0x33, 0x1c, 0x90, 0xac,
+	0xb7, 0x38, 0xc6, 0x61, 0x32, 0xc5, 0xc7, 0x0a, 0x76, 0x93, 0x34, 0x66, 0x31, 0xba, 0x77, 0xfe,
+	0x86, 0xef, 0x6a, 0x52, 0x57, 0x7d, 0xd2, 0xa4, 0xf6, 0xfd, 0x20, 0x8e, 0x83, 0x90, 0xf4, 0x04,
+	0xed, 0xd9, 0x7c, 0xd2, 0x63, 0x74, 0x46, 0x32, 0x86, 0x67, 0x89, 0x64, 0xb7, 0x8f, 0x02, 0xca,
+	0xa6, 0xf3, 0x33, 0xd7, 0x8f, 0x67, 0xbd, 0x20, 0x0e, 0xe2, 0x25, 0x25, 0x87, 0x04, 0x20, 0x4e,
+	0x8a, 0xbc, 0x7f, 0xfe, 0x28, 0x73, 0x69, 0xdc, 0xf3, 0x53, 0x7a, 0x84, 0x13, 0xda, 0xcb, 0x8d,
+	0x4d, 0xe7, 0x11, 0x17, 0xad, 0x8d, 0xec, 0x73, 0xac, 0xe4, 0x71, 0x9e, 0xc0, 0xed, 0x27, 0xdc,
+	0xac, 0x53, 0x9a, 0x31, 0x8f, 0xfc, 0x72, 0x4e, 0x32, 0x86, 0xbe, 0x01, 0x8d, 0x09, 0x0d, 0x19,
+	0x49, 0x3b, 0xd6, 0x81, 0x75, 0xd8, 0xee, 0xbf, 0xec, 0x2a, 0x01, 0xda, 0xf4, 0xbe, 0x2b, 0x78,
+	0x1e, 0x0b, 0x22, 0x4f, 0x11, 0x3b, 0xdf, 0x83, 0x7d, 0x43, 0x54, 0x96, 0xc4, 0x51, 0x46, 0x50,
+	0x0f, 0x1a, 0xf2, 0xda, 0x1d, 0xeb, 0xa0, 0x7a, 0xd8, 0xee, 0x7f, 0xe9, 0x1a, 0x59, 0x9e, 0x22,
+	0x73, 0x5e, 0x58, 0xca, 0xa2, 0xa7, 0xf3, 0x30, 0xd4, 0x16, 0x1d, 0x43, 0x5d, 0x7c, 0x56, 0x06,
+	0x7d, 0xf9, 0x1a, 0x21, 0xef, 0x25, 0xc4, 0xf7, 0x24, 0x25, 0x7a, 0x08, 0x35, 0x3c, 0x67, 0xd3,
+	0x4e, 0x45, 0xa8, 0xbd, 0x77, 0x95, 0x63, 0x30, 0x67, 0xd3, 0x61, 0x1c, 0x4d, 0x68, 0xe0, 0x09,
+	0x4a, 0x64, 0x43, 0x33, 0x09, 0x31, 0x9b, 0xc4, 0xe9, 0xac, 0x53, 0x3d, 0xb0, 0x0e, 0x5b, 0x5e,
+	0x0e, 0xa3, 0xaf, 0xc0, 0x0e, 0x0e, 0xc3, 0x91, 0x86, 0xb3, 0x4e, 0xed, 0xc0, 0x3a, 0x6c, 0x7a,
+	0xdb, 0x38, 0x0c, 0x9f, 0x6a, 0x1c, 0xfa, 0x3a, 0xec, 0xa9, 0x58, 0x8f, 0xb0, 0xef, 0xc7, 0xf3,
+	0x88, 0x75, 0xea, 0x42, 0xce, 0xae, 0x42, 0x0f, 0x24, 0xd6, 0x49, 0x61, 0xdf, 0xb8, 0xa2, 0xf2,
+	0xd4, 0x5d, 0xf3, 0x8e, 0x2d, 0x7d, 0x8d, 0x77, 0xa0, 0x91, 0x31, 0xcc, 0xe6, 0x99, 0xba, 0xc8,
+	0x03, 0x77, 0x55, 0x4a, 0x29, 0x37, 0x08, 0x86, 0x93, 0xda, 0xf3, 0x7f, 0xdf, 0xbf, 0xe5, 0x29,
+	0x76, 0xe7, 0xa3, 0xa5, 0x5f, 0xb3, 0xe9, 0xff, 0xd5, 0xaf, 0x1d, 0xd8, 0x62, 0x38, 0x0d, 0x08,
+	0xcb, 0x3a, 0xd5, 0x83, 0xea, 0x61, 0xcb, 0xd3, 0x20, 0x3a, 0x80, 0xb6, 0x1f, 0xcf, 0x92, 0x94,
+	0x64, 0x19, 0x8d, 0x23, 0xe1, 0xd3, 0x96, 0x67, 0xa2, 0xd0, 0x6b, 0xb0, 0x3f, 0x89, 0x53, 0x9f,
+	0x8c, 0x4c, 0xba, 0xba, 0xf0, 0xfd, 0x6d, 0xf1, 0x61, 0xb8, 0xc4, 0x3b, 0xbf, 0xb3, 0x60, 0xdf,
+	0xb8, 0xe2, 0x4a, 0xbf, 0x1a, 0x46, 0x55, 0x8a, 0x46, 0x2d, 0x3d, 0x5e, 0xbd, 0x99, 0xc7, 0xff,
+	0x6b, 0x41, 0xdb, 0xf8, 0x8a, 0x6e, 0x43, 0x35, 0x25, 0x13, 0x65, 0x06, 0x3f, 0xa2, 0x97, 0x8c,
+	0xe0, 0x72, 0xa4, 0x82, 0x38, 0x3e, 0x9e, 0x4c, 0x32, 0xc2, 0x44, 0x1e, 0x56, 0x3d, 0x05, 0xf1,
+	0xab, 0xb0, 0x98, 0xe1, 0x50, 0x78, 0xaa, 0xea, 0x49, 0x00, 0x0d, 0x01, 0x32, 0x86, 0x53, 0x46,
+	0xc6, 0x23, 0x2c, 0x33, 0xae, 0xdd, 0xb7, 0x5d, 0xd9, 0x5b, 0x5c, 0xdd, 0x31, 0xdc, 0x67, 0xba,
+	0xb7, 0x9c, 0x34, 0xb9, 0x95, 0x1f, 0xfe, 0xe7, 0xbe, 0xe5, 0xb5, 0x14, 0xdf, 0x80, 0x71, 0x21,
+	0xf3, 0x64, 0x8c, 0x95, 0x90, 0xc6, 0x26, 0x42, 0x14, 0xdf, 0x80, 0x39, 0xef, 0x03, 0x92, 0xc5,
+	0x4c, 0x66, 0xf1, 0x82, 0xdc, 0x20, 0xc9, 0xee, 0x42, 0x5d, 0x44, 0x57, 0xf8, 0xa5, 0xe9, 0x49,
+	0xc0, 0xf9, 0x21, 0xdc, 0x29, 0x88, 0x57, 0x01, 0xb6, 0xa1, 0x39, 0x8f, 0x18, 0x0e, 0x02, 0x32,
+	0x16, 0x4d, 0xa6, 0xe5, 0xe5, 0x30, 0x0f, 0xf3, 0x98, 0x84, 0x84, 0x91, 0xb1, 0x0e, 0xb3, 0x02,
+	0x9d, 0x77, 0x00, 0x19, 0xc1, 0xf9, 0xec, 0xb6, 0x3a, 0x3f, 0x87, 0x3b, 0x05, 0x41, 0xca, 0xaa,
+	0xa3, 0xa2, 0xa4, 0x6b, 0xfb, 0xde, 0x32, 0x1f, 0xb3, 0x78, 0x9e, 0xfa, 0x24, 0xcf, 0x47, 0x05,
+	0x3a, 0x7f, 0xb2, 0x60, 0x4f, 0x90, 0x3e, 0xc3, 0xc1, 0x0d, 0x5c, 0x8a, 0xa0, 0xc6, 0x70, 0xa0,
+	0xa5, 0x8b, 0x33, 0xfa, 0x2a, 0xec, 0x4e, 0xd2, 0x78, 0x36, 0x8a, 0xf0, 0x8c, 0x64, 0x09, 0xf6,
+	0x89, 0xea, 0x7b, 0x3b, 0x1c, 0xfb, 0xae, 0x46, 0xa2, 0x57, 0x60, 0x9b, 0xc5, 0x06, 0x91, 0xaa,
+	0x53, 0x16, 0xe7, 0x24, 0xce, 0x00, 0x6e, 0x2f, 0x6d, 0xfc, 0x4c, 0x1e, 0x70, 0xfe, 0x66, 0x29,
+	0x47, 0x0e, 0xe3, 0x68, 0x41, 0x52, 0x76, 0x83, 0xbb, 0xbe, 0x04, 0x0d, 0x59, 0xcd, 0xba, 0xae,
+	0x24, 0xc4, 0x2b, 0x30, 0xf6, 0xa9, 0xb8, 0x64, 0xd3, 0xe3, 0xc7, 0xcf, 0xbb, 0x03, 0xbd, 0x0e,
+	0x77, 0x8b, 0x57, 0x58, 0xd5, 0x83, 0x9c, 0x3f, 0xeb, 0x96, 0x3c, 0x8c, 0x93, 0x8b, 0x2f, 0xe0,
+	0xba, 0x9f, 0x5f, 0x78, 0x1f, 0xc0, 0xbe, 0x61, 0xe8, 0xca, 0x4b, 0x4d, 0x54, 0x5d, 0xbd, 0xbd,
+	0x20, 0x11, 0xcb, 0xeb, 0xaa, 0x03, 0x5b, 0x72, 0x4a, 0xc8, 0x54, 0x89, 0x6a, 0x10, 0xbd, 0x09,
+	0xf5, 0x8c, 0x46, 0xaa, 0xd4, 0x57, 0xf7, 0x9c, 0x9a, 0xe8, 0x37, 0x92, 0xdc, 0xf9, 0x57, 0x05,
+	0xee, 0x14, 0x14, 0x29, 0xab, 0x4e, 0xa0, 0x95, 0x8f, 0x51, 0x1d, 0x6b, 0xad, 0x4c, 0xa3, 0x8f,
+	0xe5, 0x6c, 0xe8, 0x1e, 0xb4, 0x96, 0xee, 0x90, 0x3e, 0x5d, 0x22, 0x44, 0x25, 0x5d, 0x24, 0xda,
+	0x99, 0xe2, 0xcc, 0x43, 0x80, 0x7d, 0xb6, 0x4c, 0x21, 0x05, 0x71, 0x5a, 0xce, 0xa8, 0xe6, 0x00,
+	0x71, 0x46, 0x18, 0x00, 0x33, 0x96, 0xd2, 0xb3, 0x39, 0x23, 0x59, 0xa7, 0x21, 0x1e, 0x99, 0x41,
+	0x89, 0x47, 0xa6, 0x78, 0x51, 0x77, 0x90, 0xcb, 0x78, 0x3b, 0x62, 0xe9, 0x85, 0x67, 0x08, 0xb5,
+	0xbf, 0x0d, 0x7b, 0x9f, 0xf8, 0xcc, 0x73, 0xff, 0x9c, 0x5c, 0xe8, 0xd7, 0xe7, 0x9c, 0x5c, 0xf0,
+	0xf8, 0x2d, 0x70, 0x38, 0xd7, 0x37, 0x94, 0xc0, 0x5b, 0x95, 0x47, 0x96, 0xf3, 0x7b, 0x0b, 0x76,
+	0x7f, 0x4c, 0x52, 0x9e, 0xd2, 0x46, 0x00, 0x17, 0x12, 0xa3, 0x44, 0x68, 0x10, 0xbd, 0x0c, 0x10,
+	0x50, 0xc6, 0xcb, 0x63, 0x46, 0x75, 0x06, 0xb6, 0x02, 0xca, 0x86, 0x02, 0x81, 0xee, 0x43, 0x1b,
+	0x27, 0x74, 0xa4, 0x99, 0xb9, 0xd3, 0x76, 0x3c, 0xc0, 0x09, 0x55, 0x0a, 0x90, 0x03, 0xdb, 0x3e,
+	0x4e, 0xf0, 0x19, 0x0d, 0x29, 0xa3, 0x84, 0x4f, 0x56, 0x3c, 0x3f, 0x0a, 0x38, 0xe7, 0x2f, 0x16,
+	0xec, 0xe5, 0x06, 0xa9, 0x40, 0x7f, 0x71, 0x16, 0x7d, 0x0d, 0xf6, 0x66, 0x34, 0x1a, 0x99, 0x44,
+	0x35, 0x41, 0xb4, 0x33, 0xa3, 0xd1, 0xe0, 0x7a, 0xcb, 0xeb, 0x9f, 0x62, 0xf9, 0x4f, 0x61, 0xfb,
+	0x34, 0x0e, 0x68, 0xee, 0x47, 0xfe, 0xe4, 0x93, 0x74, 0xa1, 0x66, 0xeb, 0x96, 0xa7, 0x20, 0x63,
+	0xac, 0xb2, 0xca, 0x8d, 0x55, 0xce, 0x1e, 0xec, 0x28, 0xc9, 0xd2, 0x21, 0xce, 0x0e, 0xb4, 0x9f,
+	0x44, 0x93, 0x58, 0x69, 0x72, 0x7e, 0x53, 0x83, 0x6d, 0x09, 0xdf, 0xd4, 0x61, 0x3c, 0x89, 0xe3,
+	0x71, 0x9e, 0xf0, 0xfc, 0x8c, 0x1e, 0x43, 0xf3, 0x6c, 0x4e, 0xc3, 0xf1, 0x39, 0x65, 0xc2, 0x39,
+	0xed, 0xfe, 0xab, 0xab, 0x53, 0xf8, 0x44, 0x51, 0x0b, 0x93, 0x72, 0x5e, 0x74, 0x0a, 0xe0, 0xc7,
+	0x11, 0xc3, 0x34, 0x22, 0xe9, 0x58, 0x0d, 0x2f, 0xaf, 0xaf, 0x96, 0x34, 0xcc, 0xe9, 0x85, 0x2c,
+	0x83, 0x1f, 0x0d, 0x61, 0x4b, 0x39, 0x4e, 0x8d, 0x30, 0x6b, 0x86, 0x37, 0x4f, 0x12, 0x0b, 0x39,
+	0x9a, 0x13, 0x7d, 0x37, 0x5f, 0x59, 0xb6, 0x84, 0x8c, 0xc3, 0x12, 0xb5, 0x99, 0x09, 0x11, 0x8a,
+	0x0f, 0xbd, 0x0b, 0x8d, 0x29, 0xc1, 0x21, 0x9b, 0x76, 0x9a, 0xa2, 0xba, 0xdf, 0x5c, 0x23, 0xc1,
+	0x88, 0x92, 0xfb, 0x7d, 0xc1, 0x28, 0x4b, 0x5a, 0x49, 0xb1, 0xbf, 0x09, 0x6d, 0x03, 0xbd, 0x51,
+	0x29, 0xff, 0xd6, 0x82, 0x6d, 0xd3, 0xf5, 0xe8, 0x31, 0x6c, 0xfd, 0x2a, 0x4e, 0xcf, 0x75, 0x27,
+	0x5e, 0xeb, 0x6d, 0xcd, 0xfc, 0x13, 0xc1, 0xe4, 0x69, 0x66, 0x33, 0x9b, 0x2a, 0xc5, 0x6c, 0xca,
+	0xdf, 0x85, 0xaa, 0xf9, 0x2e, 0xfc, 0xdd, 0x82, 0xdd, 0xa2, 0x2c, 0xb4, 0x0b, 0x15, 0x3a, 0x56,
+	0xd7, 0xa8, 0xd0, 0x31, 0x6f, 0xbb, 0xcb, 0x05, 0x4b, 0xce, 0x29, 0x4b, 0x04, 0x7a, 0x0a, 0x8d,
+	0x10, 0x9f, 0x91, 0x50, 0xcf, 0xe5, 0x8f, 0x36, 0xb1, 0xdb, 0x3d, 0x15, 0xac, 0xca, 0xad, 0x52,
+	0x0e, 0x77, 0xab, 0x81, 0xde, 0xc8, 0xad, 0x13, 0xd8, 0x2d, 0xa6, 0xe1, 0x8a, 0xea, 0xb2, 0xa1,
+	0x99, 0x92, 0x05, 0x35, 0x5c, 0x95, 0xc3, 0xa8, 0x0b, 0x90, 0x3f, 0x2c, 0x7a, 0x3d, 0x32, 0x30,
+	0xce, 0x1f, 0x2d, 0x68, 0x1b, 0x49, 0x9a, 0xbf, 0x27, 0x96, 0xf1, 0x9e, 0x5c, 0x1f, 0x89, 0x4f,
+	0xe9, 0x74, 0xad, 0x42, 0xa7, 0x3b, 0x11, 0xd5, 0x37, 0xa6, 0xfc, 0xad, 0x92, 0x9d, 0xb7, 0xdd,
+	0x77, 0xae, 0xf6, 0x1e, 0x65, 0xc1, 0x50, 0x93, 0x7a, 0x06, 0x97, 0xf3, 0xd7, 0x0a, 0xc0, 0xb2,
+	0x06, 0x8a, 0x6f, 0xa7, 0xf5, 0xc9, 0xb7, 0xf3, 0x2e, 0xd4, 0xe5, 0x62, 0x5c, 0x91, 0x1b, 0x8c,
+	0x00, 0xf8, 0xad, 0x32, 0xfa, 0x6b, 0x99, 0x30, 0x35, 0x4f, 0x9c, 0xd1, 0x10, 0xda, 0x13, 0x1a,
+	0x92, 0xec, 0x22, 0x63, 0x64, 0xa6, 0x6d, 0x7b, 0xe5, 0xaa, 0x6d, 0x8f, 0x73, 0xa2, 0x1f, 0x65,
+	0x7c, 0x9a, 0x34, 0xb9, 0xd0, 0x08, 0x76, 0x78, 0x77, 0x20, 0x11, 0x1b, 0x71, 0xa1, 0xb2, 0x45,
+	0xb7, 0xfb, 0x6f, 0x95, 0xad, 0x68, 0xd1, 0x6b, 0x48, 0xc4, 0xde, 0xe3, 0xcc, 0x32, 0x79, 0xb6,
+	0x7d, 0x03, 0x65, 0x7f, 0x07, 0xf6, 0xaf, 0x90, 0xac, 0x4b, 0xa4, 0xaa, 0x91, 0x48, 0xfd, 0x0f,
+	0x00, 0x1a, 0x52, 0x1f, 0x9a, 0xc0, 0x96, 0x8e, 0xcb, 0x9a, 0x9a, 0x2c, 0xbe, 0xcd, 0xf6, 0x51,
+	0x49, 0x6a, 0xf5, 0x0e, 0xcc, 0xa0, 0xa1, 0x36, 0xd2, 0x87, 0xa5, 0x57, 0x5b, 0xad, 0xea, 0x78,
+	0x03, 0x0e, 0xa5, 0x2e, 0x80, 0x1a, 0xff, 0x23, 0x84, 0xdc, 0x12, 0xac, 0xc6, 0x5f, 0x28, 0xbb,
+	0x57, 0x9a, 0x5e, 0x29, 0xa2, 0x50, 0xe3, 0x3f, 0x54, 0x4a, 0x29, 0x32, 0x7e, 0x2e, 0xd9, 0xbd,
+	0xd2, 0xf4, 0x52, 0xd1, 0x43, 0x4b, 0xaa, 0xca, 0xa6, 0x25, 0x55, 0x65, 0xd3, 0xcd, 0x54, 0x2d,
+	0x7f, 0x5e, 0x3c, 0xb4, 0x78, 0xb4, 0xe4, 0xbe, 0x5b, 0x2a, 0x5a, 0x85, 0xcd, 0xdb, 0x3e, 0xde,
+	0x80, 0x43, 0x39, 0x71, 0x0c, 0xd5, 0x67, 0x38, 0x40, 0x47, 0x25, 0x38, 0x97, 0xfb, 0xa8, 0xed,
+	0x96, 0x25, 0x57, 0x5a, 0x12, 0xd8, 0x52, 0x2b, 0x12, 0x2a, 0x63, 0x63, 0x71, 0x23, 0xb4, 0xfb,
+	0x9b, 0xb0, 0x2c, 0xb3, 0x90, 0x2f, 0x2f, 0xa5, 0x22, 0x66, 0xac, 0x63, 0x76, 0xaf, 0x34, 0xbd,
+	0x52, 0x14, 0x43, 0x43, 0x0e, 0xea, 0xa5, 0xe2, 0x55, 0xd8, 0x92, 0xec, 0xe3, 0x0d, 0x38, 0xf2,
+	0x04, 0x79, 0x1f, 0x6a, 0xa2, 0xf1, 0x3e, 0x28, 0x33, 0x64, 0x48, 0x3d, 0xaf, 0x96, 0x9f, 0x47,
+	0xd0, 0x2f, 0xa0, 0x2e, 0xc6, 0x4c, 0xb4, 0x86, 0xc9, 0x9c, 0x72, 0xed, 0xd7, 0x4a, 0xd1, 0x4a,
+	0x0d, 0x27, 0x3f, 0x78, 0xfe, 0xa2, 0x6b, 0xfd, 0xf3, 0x45, 0xf7, 0xd6, 0x07, 0x97, 0x5d, 0xeb,
+	0xf9, 0x65, 0xd7, 0xfa, 0xc7, 0x65, 0xd7, 0xfa, 0xe8, 0xb2, 0x6b, 0x7d, 0xf8, 0x71, 0xf7, 0xd6,
+	0x1f, 0x3e, 0xee, 0xde, 0xfa, 0xd9, 0xe1, 0xda, 0xdf, 0xee, 0xdf, 0x92, 0xf0, 0x59, 0x43, 0xac,
+	0x78, 0x6f, 0xfc, 0x6f, 0x00, 0x21, 0xd9, 0xb2, 0x08, 0xa9, 0x17, 0x00, 0x00,
 }
 
-func (m *ImageStatusResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
-	}
-	return n
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ImagesClient is the client API for Images service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ImagesClient interface {
+	// Version of the agent and of its api, exchanged with that of the client before other calls
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	// Status of an image
+	Status(ctx context.Context, in *ImageStatusRequest, opts ...grpc.CallOption) (*ImageStatusResponse, error)
+	// List images
+	List(ctx context.Context, in *ImageListRequest, opts ...grpc.CallOption) (*ImageListResponse, error)
+	// Pull an image, streaming its progress until the final response naming the image
+	Pull(ctx context.Context, in *ImagePullRequest, opts ...grpc.CallOption) (Images_PullClient, error)
+	// Push an image, streaming its progress until the final response naming the image and targets
+	Push(ctx context.Context, in *ImagePushRequest, opts ...grpc.CallOption) (Images_PushClient, error)
+	// Remove an image, or just the reference to it when it has other tags
+	Remove(ctx context.Context, in *ImageRemoveRequest, opts ...grpc.CallOption) (*ImageRemoveResponse, error)
+	// Tag an image
+	Tag(ctx context.Context, in *ImageTagRequest, opts ...grpc.CallOption) (*ImageTagResponse, error)
+	// Convert an image, e.g. to OCI media types or another layer compression, creating the target image.
+	Convert(ctx context.Context, in *ImageConvertRequest, opts ...grpc.CallOption) (*ImageConvertResponse, error)
+	// Copy an image and its content to another containerd namespace and/or name.
+	Copy(ctx context.Context, in *ImageCopyRequest, opts ...grpc.CallOption) (*ImageCopyResponse, error)
+	// Stream image, content and build events
+	Events(ctx context.Context, in *ImageEventsRequest, opts ...grpc.CallOption) (Images_EventsClient, error)
+	// Info of the agent, the node it runs on and its backends
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// Login verifies credentials by authenticating against the api of a registry, as `docker login` does
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 }
 
-func (m *ImageTagRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type imagesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewImagesClient(cc *grpc.ClientConn) ImagesClient {
+	return &imagesClient{cc}
+}
+
+func (c *imagesClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
+	return out, nil
+}
+
+func (c *imagesClient) Status(ctx context.Context, in *ImageStatusRequest, opts ...grpc.CallOption) (*ImageStatusResponse, error) {
+	out := new(ImageStatusResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Tags) > 0 {
-		for _, s := range m.Tags {
-			l = len(s)
-			n += 1 + l + sovImages(uint64(l))
-		}
+	return out, nil
+}
+
+func (c *imagesClient) List(ctx context.Context, in *ImageListRequest, opts ...grpc.CallOption) (*ImageListResponse, error) {
+	out := new(ImageListResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/List", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *ImageTagResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *imagesClient) Pull(ctx context.Context, in *ImagePullRequest, opts ...grpc.CallOption) (Images_PullClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Images_serviceDesc.Streams[0], "/k3c.services.images.v1alpha1.Images/Pull", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Image != nil {
-		l = m.Image.Size()
-		n += 1 + l + sovImages(uint64(l))
+	x := &imagesPullClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return n
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func sovImages(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+type Images_PullClient interface {
+	Recv() (*ImagePullResponse, error)
+	grpc.ClientStream
 }
-func sozImages(x uint64) (n int) {
-	return sovImages(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+type imagesPullClient struct {
+	grpc.ClientStream
 }
-func (this *ImageListRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (x *imagesPullClient) Recv() (*ImagePullResponse, error) {
+	m := new(ImagePullResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImageListRequest{`,
-		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "ImageFilter", "v1alpha2.ImageFilter", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return m, nil
 }
-func (this *ImageListResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Push(ctx context.Context, in *ImagePushRequest, opts ...grpc.CallOption) (Images_PushClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Images_serviceDesc.Streams[1], "/k3c.services.images.v1alpha1.Images/Push", opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForImages := "[]*Image{"
-	for _, f := range this.Images {
-		repeatedStringForImages += strings.Replace(fmt.Sprintf("%v", f), "Image", "v1alpha2.Image", 1) + ","
+	x := &imagesPushClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	repeatedStringForImages += "}"
-	s := strings.Join([]string{`&ImageListResponse{`,
-		`Images:` + repeatedStringForImages + `,`,
-		`}`,
-	}, "")
-	return s
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (this *ImagePullRequest) String() string {
-	if this == nil {
-		return "nil"
+
+type Images_PushClient interface {
+	Recv() (*ImagePushResponse, error)
+	grpc.ClientStream
+}
+
+type imagesPushClient struct {
+	grpc.ClientStream
+}
+
+func (x *imagesPushClient) Recv() (*ImagePushResponse, error) {
+	m := new(ImagePushResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImagePullRequest{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
-		`Auth:` + strings.Replace(fmt.Sprintf("%v", this.Auth), "AuthConfig", "v1alpha2.AuthConfig", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return m, nil
 }
-func (this *ImagePullResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Remove(ctx context.Context, in *ImageRemoveRequest, opts ...grpc.CallOption) (*ImageRemoveResponse, error) {
+	out := new(ImageRemoveResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImagePullResponse{`,
-		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ImagePushRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Tag(ctx context.Context, in *ImageTagRequest, opts ...grpc.CallOption) (*ImageTagResponse, error) {
+	out := new(ImageTagResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Tag", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImagePushRequest{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
-		`Auth:` + strings.Replace(fmt.Sprintf("%v", this.Auth), "AuthConfig", "v1alpha2.AuthConfig", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ImagePushResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Convert(ctx context.Context, in *ImageConvertRequest, opts ...grpc.CallOption) (*ImageConvertResponse, error) {
+	out := new(ImageConvertResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Convert", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImagePushResponse{`,
-		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ImageProgressRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Copy(ctx context.Context, in *ImageCopyRequest, opts ...grpc.CallOption) (*ImageCopyResponse, error) {
+	out := new(ImageCopyResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Copy", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImageProgressRequest{`,
-		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ImageProgressResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Events(ctx context.Context, in *ImageEventsRequest, opts ...grpc.CallOption) (Images_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Images_serviceDesc.Streams[2], "/k3c.services.images.v1alpha1.Images/Events", opts...)
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForStatus := "[]ImageStatus{"
-	for _, f := range this.Status {
-		repeatedStringForStatus += strings.Replace(strings.Replace(f.String(), "ImageStatus", "ImageStatus", 1), `&`, ``, 1) + ","
+	x := &imagesEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	repeatedStringForStatus += "}"
-	s := strings.Join([]string{`&ImageProgressResponse{`,
-		`Status:` + repeatedStringForStatus + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ImageStatus) String() string {
-	if this == nil {
-		return "nil"
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImageStatus{`,
-		`Ref:` + fmt.Sprintf("%v", this.Ref) + `,`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
-		`Total:` + fmt.Sprintf("%v", this.Total) + `,`,
-		`StartedAt:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.StartedAt), "Timestamp", "timestamp.Timestamp", 1), `&`, ``, 1) + `,`,
-		`UpdatedAt:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.UpdatedAt), "Timestamp", "timestamp.Timestamp", 1), `&`, ``, 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return x, nil
 }
-func (this *ImageRemoveRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ImageRemoveRequest{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+
+type Images_EventsClient interface {
+	Recv() (*ImageEventsResponse, error)
+	grpc.ClientStream
 }
-func (this *ImageRemoveResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ImageRemoveResponse{`,
-		`}`,
-	}, "")
-	return s
+
+type imagesEventsClient struct {
+	grpc.ClientStream
 }
-func (this *ImageStatusRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (x *imagesEventsClient) Recv() (*ImageEventsResponse, error) {
+	m := new(ImageEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImageStatusRequest{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return m, nil
 }
-func (this *ImageStatusResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImageStatusResponse{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "Image", "v1alpha2.Image", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ImageTagRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *imagesClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/k3c.services.images.v1alpha1.Images/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ImageTagRequest{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
-		`Tags:` + fmt.Sprintf("%v", this.Tags) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ImageTagResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&ImageTagResponse{`,
-		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "Image", "v1alpha2.Image", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+
+// ImagesServer is the server API for Images service.
+type ImagesServer interface {
+	// Version of the agent and of its api, exchanged with that of the client before other calls
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	// Status of an image
+	Status(context.Context, *ImageStatusRequest) (*ImageStatusResponse, error)
+	// List images
+	List(context.Context, *ImageListRequest) (*ImageListResponse, error)
+	// Pull an image, streaming its progress until the final response naming the image
+	Pull(*ImagePullRequest, Images_PullServer) error
+	// Push an image, streaming its progress until the final response naming the image and targets
+	Push(*ImagePushRequest, Images_PushServer) error
+	// Remove an image, or just the reference to it when it has other tags
+	Remove(context.Context, *ImageRemoveRequest) (*ImageRemoveResponse, error)
+	// Tag an image
+	Tag(context.Context, *ImageTagRequest) (*ImageTagResponse, error)
+	// Convert an image, e.g. to OCI media types or another layer compression, creating the target image.
+	Convert(context.Context, *ImageConvertRequest) (*ImageConvertResponse, error)
+	// Copy an image and its content to another containerd namespace and/or name.
+	Copy(context.Context, *ImageCopyRequest) (*ImageCopyResponse, error)
+	// Stream image, content and build events
+	Events(*ImageEventsRequest, Images_EventsServer) error
+	// Info of the agent, the node it runs on and its backends
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	// Login verifies credentials by authenticating against the api of a registry, as `docker login` does
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 }
-func valueToStringImages(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
-	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+
+// UnimplementedImagesServer can be embedded to have forward compatible implementations.
+type UnimplementedImagesServer struct {
 }
-func (m *ImageListRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+
+func (*UnimplementedImagesServer) Version(ctx context.Context, req *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (*UnimplementedImagesServer) Status(ctx context.Context, req *ImageStatusRequest) (*ImageStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (*UnimplementedImagesServer) List(ctx context.Context, req *ImageListRequest) (*ImageListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedImagesServer) Pull(req *ImagePullRequest, srv Images_PullServer) error {
+	return status.Errorf(codes.Unimplemented, "method Pull not implemented")
+}
+func (*UnimplementedImagesServer) Push(req *ImagePushRequest, srv Images_PushServer) error {
+	return status.Errorf(codes.Unimplemented, "method Push not implemented")
+}
+func (*UnimplementedImagesServer) Remove(ctx context.Context, req *ImageRemoveRequest) (*ImageRemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (*UnimplementedImagesServer) Tag(ctx context.Context, req *ImageTagRequest) (*ImageTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tag not implemented")
+}
+func (*UnimplementedImagesServer) Convert(ctx context.Context, req *ImageConvertRequest) (*ImageConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (*UnimplementedImagesServer) Copy(ctx context.Context, req *ImageCopyRequest) (*ImageCopyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Copy not implemented")
+}
+func (*UnimplementedImagesServer) Events(req *ImageEventsRequest, srv Images_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (*UnimplementedImagesServer) Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (*UnimplementedImagesServer) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+
+func RegisterImagesServer(s *grpc.Server, srv ImagesServer) {
+	s.RegisterService(&_Images_serviceDesc, srv)
+}
+
+func _Images_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Status(ctx, req.(*ImageStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).List(ctx, req.(*ImageListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Pull_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImagePullRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImagesServer).Pull(m, &imagesPullServer{stream})
+}
+
+type Images_PullServer interface {
+	Send(*ImagePullResponse) error
+	grpc.ServerStream
+}
+
+type imagesPullServer struct {
+	grpc.ServerStream
+}
+
+func (x *imagesPullServer) Send(m *ImagePullResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Images_Push_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImagePushRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImagesServer).Push(m, &imagesPushServer{stream})
+}
+
+type Images_PushServer interface {
+	Send(*ImagePushResponse) error
+	grpc.ServerStream
+}
+
+type imagesPushServer struct {
+	grpc.ServerStream
+}
+
+func (x *imagesPushServer) Send(m *ImagePushResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Images_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Remove(ctx, req.(*ImageRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Tag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Tag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Tag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Tag(ctx, req.(*ImageTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Convert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Convert(ctx, req.(*ImageConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Copy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Copy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Copy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Copy(ctx, req.(*ImageCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImageEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImagesServer).Events(m, &imagesEventsServer{stream})
+}
+
+type Images_EventsServer interface {
+	Send(*ImageEventsResponse) error
+	grpc.ServerStream
+}
+
+type imagesEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *imagesEventsServer) Send(m *ImageEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Images_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Images_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImagesServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/k3c.services.images.v1alpha1.Images/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImagesServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Images_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "k3c.services.images.v1alpha1.Images",
+	HandlerType: (*ImagesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Version",
+			Handler:    _Images_Version_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Images_Status_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Images_List_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _Images_Remove_Handler,
+		},
+		{
+			MethodName: "Tag",
+			Handler:    _Images_Tag_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _Images_Convert_Handler,
+		},
+		{
+			MethodName: "Copy",
+			Handler:    _Images_Copy_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Images_Info_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _Images_Login_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Pull",
+			Handler:       _Images_Pull_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Push",
+			Handler:       _Images_Push_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Events",
+			Handler:       _Images_Events_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/apis/services/images/v1alpha1/images.proto",
+}
+
+func (m *ImageListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Filter != nil {
+		{
+			size, err := m.Filter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageListResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Images) > 0 {
+		for iNdEx := len(m.Images) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Images[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImagePullRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImagePullRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImagePullRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ServiceAccount) > 0 {
+		i -= len(m.ServiceAccount)
+		copy(dAtA[i:], m.ServiceAccount)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.ServiceAccount)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.AllPlatforms {
+		i--
+		if m.AllPlatforms {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Platform) > 0 {
+		i -= len(m.Platform)
+		copy(dAtA[i:], m.Platform)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Platform)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Auth) > 0 {
+		for iNdEx := len(m.Auth) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Auth[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImagePullResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImagePullResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImagePullResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Status) > 0 {
+		for iNdEx := len(m.Status) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Status[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Image) > 0 {
+		i -= len(m.Image)
+		copy(dAtA[i:], m.Image)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImagePushRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImagePushRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImagePushRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ForceCompression {
+		i--
+		if m.ForceCompression {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Compression) > 0 {
+		i -= len(m.Compression)
+		copy(dAtA[i:], m.Compression)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Compression)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Targets) > 0 {
+		for iNdEx := len(m.Targets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Targets[iNdEx])
+			copy(dAtA[i:], m.Targets[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Targets[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Auth) > 0 {
+		for iNdEx := len(m.Auth) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Auth[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImagePushResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImagePushResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImagePushResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Status) > 0 {
+		for iNdEx := len(m.Status) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Status[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Targets) > 0 {
+		for iNdEx := len(m.Targets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Targets[iNdEx])
+			copy(dAtA[i:], m.Targets[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Targets[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Image) > 0 {
+		i -= len(m.Image)
+		copy(dAtA[i:], m.Image)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintImages(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x32
+	n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.StartedAt):])
+	if err5 != nil {
+		return 0, err5
+	}
+	i -= n5
+	i = encodeVarintImages(dAtA, i, uint64(n5))
+	i--
+	dAtA[i] = 0x2a
+	if m.Total != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Offset != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Ref) > 0 {
+		i -= len(m.Ref)
+		copy(dAtA[i:], m.Ref)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Ref)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageRemoveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageRemoveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageRemoveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Force {
+		i--
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageRemoveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageRemoveResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageRemoveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Deleted) > 0 {
+		for iNdEx := len(m.Deleted) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Deleted[iNdEx])
+			copy(dAtA[i:], m.Deleted[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Deleted[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Untagged) > 0 {
+		for iNdEx := len(m.Untagged) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Untagged[iNdEx])
+			copy(dAtA[i:], m.Untagged[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Untagged[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sources) > 0 {
+		for iNdEx := len(m.Sources) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Sources[iNdEx])
+			copy(dAtA[i:], m.Sources[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Sources[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageTagRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageTagRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageTagRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ToNamespace) > 0 {
+		i -= len(m.ToNamespace)
+		copy(dAtA[i:], m.ToNamespace)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.ToNamespace)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.FromNamespace) > 0 {
+		i -= len(m.FromNamespace)
+		copy(dAtA[i:], m.FromNamespace)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.FromNamespace)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageTagResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageTagResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageTagResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageConvertRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageConvertRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageConvertRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ForceCompression {
+		i--
+		if m.ForceCompression {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Compression) > 0 {
+		i -= len(m.Compression)
+		copy(dAtA[i:], m.Compression)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Compression)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Oci {
+		i--
+		if m.Oci {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Target) > 0 {
+		i -= len(m.Target)
+		copy(dAtA[i:], m.Target)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Target)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageConvertResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageConvertResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageConvertResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Image) > 0 {
+		i -= len(m.Image)
+		copy(dAtA[i:], m.Image)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageCopyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageCopyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageCopyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ToNamespace) > 0 {
+		i -= len(m.ToNamespace)
+		copy(dAtA[i:], m.ToNamespace)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.ToNamespace)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.FromNamespace) > 0 {
+		i -= len(m.FromNamespace)
+		copy(dAtA[i:], m.FromNamespace)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.FromNamespace)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Target) > 0 {
+		i -= len(m.Target)
+		copy(dAtA[i:], m.Target)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Target)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Image != nil {
+		{
+			size, err := m.Image.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageCopyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageCopyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageCopyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Image) > 0 {
+		i -= len(m.Image)
+		copy(dAtA[i:], m.Image)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageEventsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageEventsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageEventsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Since != nil {
+		n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Since, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Since):])
+		if err13 != nil {
+			return 0, err13
+		}
+		i -= n13
+		i = encodeVarintImages(dAtA, i, uint64(n13))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Filters) > 0 {
+		for iNdEx := len(m.Filters) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Filters[iNdEx])
+			copy(dAtA[i:], m.Filters[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Filters[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImageEventsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImageEventsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImageEventsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Attributes) > 0 {
+		for k := range m.Attributes {
+			v := m.Attributes[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintImages(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintImages(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintImages(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	n14, err14 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintImages(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *VersionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.ApiVersion != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.ApiVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.GitCommit) > 0 {
+		i -= len(m.GitCommit)
+		copy(dAtA[i:], m.GitCommit)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.GitCommit)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VersionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.MinApiVersion != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.MinApiVersion))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ApiVersion != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.ApiVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.GitCommit) > 0 {
+		i -= len(m.GitCommit)
+		copy(dAtA[i:], m.GitCommit)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.GitCommit)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LoginRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoginRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoginRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Auth != nil {
+		{
+			size, err := m.Auth.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Server) > 0 {
+		i -= len(m.Server)
+		copy(dAtA[i:], m.Server)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Server)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LoginResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LoginResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoginResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *InfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *InfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Health) > 0 {
+		for k := range m.Health {
+			v := m.Health[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintImages(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintImages(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintImages(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Images != nil {
+		{
+			size, err := m.Images.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Runtime != nil {
+		{
+			size, err := m.Runtime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Containerd != nil {
+		{
+			size, err := m.Containerd.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Buildkit != nil {
+		{
+			size, err := m.Buildkit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintImages(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Node) > 0 {
+		i -= len(m.Node)
+		copy(dAtA[i:], m.Node)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Node)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.GitCommit) > 0 {
+		i -= len(m.GitCommit)
+		copy(dAtA[i:], m.GitCommit)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.GitCommit)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BuildkitInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BuildkitInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BuildkitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Image) > 0 {
+		i -= len(m.Image)
+		copy(dAtA[i:], m.Image)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Image)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Workers) > 0 {
+		for iNdEx := len(m.Workers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Workers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BuildkitWorker) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BuildkitWorker) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BuildkitWorker) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for k := range m.Labels {
+			v := m.Labels[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintImages(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintImages(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintImages(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Platforms) > 0 {
+		for iNdEx := len(m.Platforms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Platforms[iNdEx])
+			copy(dAtA[i:], m.Platforms[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Platforms[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContainerdInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContainerdInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContainerdInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespaces) > 0 {
+		for iNdEx := len(m.Namespaces) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Namespaces[iNdEx])
+			copy(dAtA[i:], m.Namespaces[iNdEx])
+			i = encodeVarintImages(dAtA, i, uint64(len(m.Namespaces[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Revision) > 0 {
+		i -= len(m.Revision)
+		copy(dAtA[i:], m.Revision)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Revision)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RuntimeInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RuntimeInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RuntimeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Conditions) > 0 {
+		for iNdEx := len(m.Conditions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Conditions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ApiVersion) > 0 {
+		i -= len(m.ApiVersion)
+		copy(dAtA[i:], m.ApiVersion)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.ApiVersion)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImagesInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImagesInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImagesInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ContentSizes) > 0 {
+		for k := range m.ContentSizes {
+			v := m.ContentSizes[k]
+			baseI := i
+			i = encodeVarintImages(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintImages(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintImages(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Filesystems) > 0 {
+		for iNdEx := len(m.Filesystems) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Filesystems[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintImages(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Count != 0 {
+		i = encodeVarintImages(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintImages(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintImages(dAtA []byte, offset int, v uint64) int {
+	offset -= sovImages(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ImageListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Filter != nil {
+		l = m.Filter.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Images) > 0 {
+		for _, e := range m.Images {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImagePullRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Auth) > 0 {
+		for _, e := range m.Auth {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	l = len(m.Platform)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.AllPlatforms {
+		n += 2
+	}
+	l = len(m.ServiceAccount)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImagePullResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Status) > 0 {
+		for _, e := range m.Status {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImagePushRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Auth) > 0 {
+		for _, e := range m.Auth {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	if len(m.Targets) > 0 {
+		for _, s := range m.Targets {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.ForceCompression {
+		n += 2
+	}
+	return n
+}
+
+func (m *ImagePushResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Targets) > 0 {
+		for _, s := range m.Targets {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	if len(m.Status) > 0 {
+		for _, e := range m.Status {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImageStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Ref)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovImages(uint64(m.Offset))
+	}
+	if m.Total != 0 {
+		n += 1 + sovImages(uint64(m.Total))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.StartedAt)
+	n += 1 + l + sovImages(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)
+	n += 1 + l + sovImages(uint64(l))
+	return n
+}
+
+func (m *ImageRemoveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	return n
+}
+
+func (m *ImageRemoveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Untagged) > 0 {
+		for _, s := range m.Untagged {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	if len(m.Deleted) > 0 {
+		for _, s := range m.Deleted {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImageStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Sources) > 0 {
+		for _, s := range m.Sources {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImageTagRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	l = len(m.FromNamespace)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.ToNamespace)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageTagResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageConvertRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Oci {
+		n += 2
+	}
+	l = len(m.Compression)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.ForceCompression {
+		n += 2
+	}
+	return n
+}
+
+func (m *ImageConvertResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageCopyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Image != nil {
+		l = m.Image.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.FromNamespace)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.ToNamespace)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageCopyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageEventsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Filters) > 0 {
+		for _, s := range m.Filters {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	if m.Since != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Since)
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *ImageEventsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovImages(uint64(l))
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Attributes) > 0 {
+		for k, v := range m.Attributes {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovImages(uint64(len(k))) + 1 + len(v) + sovImages(uint64(len(v)))
+			n += mapEntrySize + 1 + sovImages(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *VersionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.GitCommit)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.ApiVersion != 0 {
+		n += 1 + sovImages(uint64(m.ApiVersion))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *VersionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.GitCommit)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.ApiVersion != 0 {
+		n += 1 + sovImages(uint64(m.ApiVersion))
+	}
+	if m.MinApiVersion != 0 {
+		n += 1 + sovImages(uint64(m.MinApiVersion))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LoginRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Server)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Auth != nil {
+		l = m.Auth.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *LoginResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *InfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *InfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.GitCommit)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Node)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Buildkit != nil {
+		l = m.Buildkit.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Containerd != nil {
+		l = m.Containerd.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Runtime != nil {
+		l = m.Runtime.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Images != nil {
+		l = m.Images.Size()
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Health) > 0 {
+		for k, v := range m.Health {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovImages(uint64(len(k))) + 1 + len(v) + sovImages(uint64(len(v)))
+			n += mapEntrySize + 1 + sovImages(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *BuildkitInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Workers) > 0 {
+		for _, e := range m.Workers {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	return n
+}
+
+func (m *BuildkitWorker) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Platforms) > 0 {
+		for _, s := range m.Platforms {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovImages(uint64(len(k))) + 1 + len(v) + sovImages(uint64(len(v)))
+			n += mapEntrySize + 1 + sovImages(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ContainerdInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Revision)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Namespaces) > 0 {
+		for _, s := range m.Namespaces {
+			l = len(s)
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RuntimeInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	l = len(m.ApiVersion)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if len(m.Conditions) > 0 {
+		for _, e := range m.Conditions {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ImagesInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovImages(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovImages(uint64(m.Count))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovImages(uint64(m.Size_))
+	}
+	if len(m.Filesystems) > 0 {
+		for _, e := range m.Filesystems {
+			l = e.Size()
+			n += 1 + l + sovImages(uint64(l))
+		}
+	}
+	if len(m.ContentSizes) > 0 {
+		for k, v := range m.ContentSizes {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovImages(uint64(len(k))) + 1 + sovImages(uint64(v))
+			n += mapEntrySize + 1 + sovImages(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func sovImages(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozImages(x uint64) (n int) {
+	return sovImages(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ImageListRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageListRequest{`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "ImageFilter", "v1alpha2.ImageFilter", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageListResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForImages := "[]*Image{"
+	for _, f := range this.Images {
+		repeatedStringForImages += strings.Replace(fmt.Sprintf("%v", f), "Image", "v1alpha2.Image", 1) + ","
+	}
+	repeatedStringForImages += "}"
+	s := strings.Join([]string{`&ImageListResponse{`,
+		`Images:` + repeatedStringForImages + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImagePullRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAuth := "[]*AuthConfig{"
+	for _, f := range this.Auth {
+		repeatedStringForAuth += strings.Replace(fmt.Sprintf("%v", f), "AuthConfig", "v1alpha2.AuthConfig", 1) + ","
+	}
+	repeatedStringForAuth += "}"
+	s := strings.Join([]string{`&ImagePullRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`Auth:` + repeatedStringForAuth + `,`,
+		`Platform:` + fmt.Sprintf("%v", this.Platform) + `,`,
+		`AllPlatforms:` + fmt.Sprintf("%v", this.AllPlatforms) + `,`,
+		`ServiceAccount:` + fmt.Sprintf("%v", this.ServiceAccount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImagePullResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForStatus := "[]ImageStatus{"
+	for _, f := range this.Status {
+		repeatedStringForStatus += strings.Replace(strings.Replace(f.String(), "ImageStatus", "ImageStatus", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForStatus += "}"
+	s := strings.Join([]string{`&ImagePullResponse{`,
+		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
+		`Status:` + repeatedStringForStatus + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImagePushRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAuth := "[]*AuthConfig{"
+	for _, f := range this.Auth {
+		repeatedStringForAuth += strings.Replace(fmt.Sprintf("%v", f), "AuthConfig", "v1alpha2.AuthConfig", 1) + ","
+	}
+	repeatedStringForAuth += "}"
+	s := strings.Join([]string{`&ImagePushRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`Auth:` + repeatedStringForAuth + `,`,
+		`Targets:` + fmt.Sprintf("%v", this.Targets) + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
+		`ForceCompression:` + fmt.Sprintf("%v", this.ForceCompression) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImagePushResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForStatus := "[]ImageStatus{"
+	for _, f := range this.Status {
+		repeatedStringForStatus += strings.Replace(strings.Replace(f.String(), "ImageStatus", "ImageStatus", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForStatus += "}"
+	s := strings.Join([]string{`&ImagePushResponse{`,
+		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
+		`Targets:` + fmt.Sprintf("%v", this.Targets) + `,`,
+		`Status:` + repeatedStringForStatus + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageStatus{`,
+		`Ref:` + fmt.Sprintf("%v", this.Ref) + `,`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`Total:` + fmt.Sprintf("%v", this.Total) + `,`,
+		`StartedAt:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.StartedAt), "Timestamp", "timestamp.Timestamp", 1), `&`, ``, 1) + `,`,
+		`UpdatedAt:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.UpdatedAt), "Timestamp", "timestamp.Timestamp", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageRemoveRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageRemoveRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`Force:` + fmt.Sprintf("%v", this.Force) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageRemoveResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageRemoveResponse{`,
+		`Untagged:` + fmt.Sprintf("%v", this.Untagged) + `,`,
+		`Deleted:` + fmt.Sprintf("%v", this.Deleted) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageStatusRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageStatusRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageStatusResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageStatusResponse{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "Image", "v1alpha2.Image", 1) + `,`,
+		`Sources:` + fmt.Sprintf("%v", this.Sources) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageTagRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageTagRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`Tags:` + fmt.Sprintf("%v", this.Tags) + `,`,
+		`FromNamespace:` + fmt.Sprintf("%v", this.FromNamespace) + `,`,
+		`ToNamespace:` + fmt.Sprintf("%v", this.ToNamespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageTagResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageTagResponse{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "Image", "v1alpha2.Image", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageConvertRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageConvertRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`Target:` + fmt.Sprintf("%v", this.Target) + `,`,
+		`Oci:` + fmt.Sprintf("%v", this.Oci) + `,`,
+		`Compression:` + fmt.Sprintf("%v", this.Compression) + `,`,
+		`ForceCompression:` + fmt.Sprintf("%v", this.ForceCompression) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageConvertResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageConvertResponse{`,
+		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageCopyRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageCopyRequest{`,
+		`Image:` + strings.Replace(fmt.Sprintf("%v", this.Image), "ImageSpec", "v1alpha2.ImageSpec", 1) + `,`,
+		`Target:` + fmt.Sprintf("%v", this.Target) + `,`,
+		`FromNamespace:` + fmt.Sprintf("%v", this.FromNamespace) + `,`,
+		`ToNamespace:` + fmt.Sprintf("%v", this.ToNamespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageCopyResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageCopyResponse{`,
+		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageEventsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ImageEventsRequest{`,
+		`Filters:` + fmt.Sprintf("%v", this.Filters) + `,`,
+		`Since:` + strings.Replace(fmt.Sprintf("%v", this.Since), "Timestamp", "timestamp.Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImageEventsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForAttributes := make([]string, 0, len(this.Attributes))
+	for k, _ := range this.Attributes {
+		keysForAttributes = append(keysForAttributes, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForAttributes)
+	mapStringForAttributes := "map[string]string{"
+	for _, k := range keysForAttributes {
+		mapStringForAttributes += fmt.Sprintf("%v: %v,", k, this.Attributes[k])
+	}
+	mapStringForAttributes += "}"
+	s := strings.Join([]string{`&ImageEventsResponse{`,
+		`Timestamp:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Timestamp), "Timestamp", "timestamp.Timestamp", 1), `&`, ``, 1) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Action:` + fmt.Sprintf("%v", this.Action) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Attributes:` + mapStringForAttributes + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *VersionRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&VersionRequest{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`GitCommit:` + fmt.Sprintf("%v", this.GitCommit) + `,`,
+		`ApiVersion:` + fmt.Sprintf("%v", this.ApiVersion) + `,`,
+		`Capabilities:` + fmt.Sprintf("%v", this.Capabilities) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *VersionResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&VersionResponse{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`GitCommit:` + fmt.Sprintf("%v", this.GitCommit) + `,`,
+		`ApiVersion:` + fmt.Sprintf("%v", this.ApiVersion) + `,`,
+		`MinApiVersion:` + fmt.Sprintf("%v", this.MinApiVersion) + `,`,
+		`Capabilities:` + fmt.Sprintf("%v", this.Capabilities) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoginRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoginRequest{`,
+		`Server:` + fmt.Sprintf("%v", this.Server) + `,`,
+		`Auth:` + strings.Replace(fmt.Sprintf("%v", this.Auth), "AuthConfig", "v1alpha2.AuthConfig", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LoginResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LoginResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *InfoRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&InfoRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *InfoResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForHealth := make([]string, 0, len(this.Health))
+	for k, _ := range this.Health {
+		keysForHealth = append(keysForHealth, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForHealth)
+	mapStringForHealth := "map[string]string{"
+	for _, k := range keysForHealth {
+		mapStringForHealth += fmt.Sprintf("%v: %v,", k, this.Health[k])
+	}
+	mapStringForHealth += "}"
+	s := strings.Join([]string{`&InfoResponse{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`GitCommit:` + fmt.Sprintf("%v", this.GitCommit) + `,`,
+		`Node:` + fmt.Sprintf("%v", this.Node) + `,`,
+		`Buildkit:` + strings.Replace(this.Buildkit.String(), "BuildkitInfo", "BuildkitInfo", 1) + `,`,
+		`Containerd:` + strings.Replace(this.Containerd.String(), "ContainerdInfo", "ContainerdInfo", 1) + `,`,
+		`Runtime:` + strings.Replace(this.Runtime.String(), "RuntimeInfo", "RuntimeInfo", 1) + `,`,
+		`Images:` + strings.Replace(this.Images.String(), "ImagesInfo", "ImagesInfo", 1) + `,`,
+		`Health:` + mapStringForHealth + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BuildkitInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForWorkers := "[]*BuildkitWorker{"
+	for _, f := range this.Workers {
+		repeatedStringForWorkers += strings.Replace(f.String(), "BuildkitWorker", "BuildkitWorker", 1) + ","
+	}
+	repeatedStringForWorkers += "}"
+	s := strings.Join([]string{`&BuildkitInfo{`,
+		`Workers:` + repeatedStringForWorkers + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`Image:` + fmt.Sprintf("%v", this.Image) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BuildkitWorker) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForLabels := make([]string, 0, len(this.Labels))
+	for k, _ := range this.Labels {
+		keysForLabels = append(keysForLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabels)
+	mapStringForLabels := "map[string]string{"
+	for _, k := range keysForLabels {
+		mapStringForLabels += fmt.Sprintf("%v: %v,", k, this.Labels[k])
+	}
+	mapStringForLabels += "}"
+	s := strings.Join([]string{`&BuildkitWorker{`,
+		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Platforms:` + fmt.Sprintf("%v", this.Platforms) + `,`,
+		`Labels:` + mapStringForLabels + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ContainerdInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ContainerdInfo{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`Revision:` + fmt.Sprintf("%v", this.Revision) + `,`,
+		`Namespaces:` + fmt.Sprintf("%v", this.Namespaces) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RuntimeInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForConditions := "[]*RuntimeCondition{"
+	for _, f := range this.Conditions {
+		repeatedStringForConditions += strings.Replace(fmt.Sprintf("%v", f), "RuntimeCondition", "v1alpha2.RuntimeCondition", 1) + ","
+	}
+	repeatedStringForConditions += "}"
+	s := strings.Join([]string{`&RuntimeInfo{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`ApiVersion:` + fmt.Sprintf("%v", this.ApiVersion) + `,`,
+		`Conditions:` + repeatedStringForConditions + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ImagesInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForFilesystems := "[]*FilesystemUsage{"
+	for _, f := range this.Filesystems {
+		repeatedStringForFilesystems += strings.Replace(fmt.Sprintf("%v", f), "FilesystemUsage", "v1alpha2.FilesystemUsage", 1) + ","
+	}
+	repeatedStringForFilesystems += "}"
+	keysForContentSizes := make([]string, 0, len(this.ContentSizes))
+	for k, _ := range this.ContentSizes {
+		keysForContentSizes = append(keysForContentSizes, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForContentSizes)
+	mapStringForContentSizes := "map[string]int64{"
+	for _, k := range keysForContentSizes {
+		mapStringForContentSizes += fmt.Sprintf("%v: %v,", k, this.ContentSizes[k])
+	}
+	mapStringForContentSizes += "}"
+	s := strings.Join([]string{`&ImagesInfo{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Count:` + fmt.Sprintf("%v", this.Count) + `,`,
+		`Size_:` + fmt.Sprintf("%v", this.Size_) + `,`,
+		`Filesystems:` + repeatedStringForFilesystems + `,`,
+		`ContentSizes:` + mapStringForContentSizes + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringImages(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ImageListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Filter == nil {
+				m.Filter = &v1alpha2.ImageFilter{}
+			}
+			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageListResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageListResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Images", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Images = append(m.Images, &v1alpha2.Image{})
+			if err := m.Images[len(m.Images)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImagePullRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImagePullRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImagePullRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Auth", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Auth = append(m.Auth, &v1alpha2.AuthConfig{})
+			if err := m.Auth[len(m.Auth)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Platform = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllPlatforms", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllPlatforms = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAccount", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceAccount = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImagePullResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImagePullResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImagePullResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = append(m.Status, ImageStatus{})
+			if err := m.Status[len(m.Status)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImagePushRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImagePushRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImagePushRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Auth", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Auth = append(m.Auth, &v1alpha2.AuthConfig{})
+			if err := m.Auth[len(m.Auth)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Targets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Targets = append(m.Targets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForceCompression", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ForceCompression = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImagePushResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImagePushResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImagePushResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Targets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Targets = append(m.Targets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = append(m.Status, ImageStatus{})
+			if err := m.Status[len(m.Status)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ref = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageRemoveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageRemoveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageRemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageRemoveResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageRemoveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageRemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Untagged", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Untagged = append(m.Untagged, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deleted = append(m.Deleted, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.Image{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sources", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sources = append(m.Sources, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageTagRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageTagRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageTagRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FromNamespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ToNamespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageTagResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageTagResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageTagResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.Image{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageConvertRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageConvertRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageConvertRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Oci", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Oci = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForceCompression", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ForceCompression = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageConvertResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageConvertResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageConvertResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageCopyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageCopyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageCopyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Image == nil {
+				m.Image = &v1alpha2.ImageSpec{}
+			}
+			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Target = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FromNamespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToNamespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ToNamespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageCopyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageCopyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageCopyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageEventsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageEventsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageEventsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filters", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Filters = append(m.Filters, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Since == nil {
+				m.Since = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Since, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImageEventsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImageEventsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImageEventsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Attributes == nil {
+				m.Attributes = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowImages
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipImages(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthImages
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Attributes[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GitCommit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GitCommit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApiVersion", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ApiVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApiVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImageListRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -2264,27 +8222,23 @@ func (m *ImageListRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Filter == nil {
-				m.Filter = &v1alpha2.ImageFilter{}
-			}
-			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2307,7 +8261,7 @@ func (m *ImageListRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImageListResponse) Unmarshal(dAtA []byte) error {
+func (m *VersionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2330,17 +8284,17 @@ func (m *ImageListResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImageListResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: VersionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Images", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -2350,25 +8304,125 @@ func (m *ImageListResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Images = append(m.Images, &v1alpha2.Image{})
-			if err := m.Images[len(m.Images)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GitCommit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GitCommit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApiVersion", wireType)
+			}
+			m.ApiVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApiVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinApiVersion", wireType)
+			}
+			m.MinApiVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinApiVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2391,7 +8445,7 @@ func (m *ImageListResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImagePullRequest) Unmarshal(dAtA []byte) error {
+func (m *LoginRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2414,17 +8468,17 @@ func (m *ImagePullRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImagePullRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LoginRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImagePullRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LoginRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Server", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -2434,27 +8488,23 @@ func (m *ImagePullRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.ImageSpec{}
-			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Server = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
@@ -2488,10 +8538,110 @@ func (m *ImagePullRequest) Unmarshal(dAtA []byte) error {
 			if m.Auth == nil {
 				m.Auth = &v1alpha2.AuthConfig{}
 			}
-			if err := m.Auth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if err := m.Auth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LoginResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LoginResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LoginResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipImages(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthImages
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowImages
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipImages(dAtA[iNdEx:])
@@ -2513,7 +8663,7 @@ func (m *ImagePullRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImagePullResponse) Unmarshal(dAtA []byte) error {
+func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2536,15 +8686,15 @@ func (m *ImagePullResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImagePullResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: InfoResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImagePullResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2572,61 +8722,75 @@ func (m *ImagePullResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipImages(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GitCommit", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImagePushRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
+			m.GitCommit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImagePushRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImagePushRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Buildkit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2653,16 +8817,16 @@ func (m *ImagePushRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.ImageSpec{}
+			if m.Buildkit == nil {
+				m.Buildkit = &BuildkitInfo{}
 			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Buildkit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Auth", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Containerd", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2689,68 +8853,18 @@ func (m *ImagePushRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Auth == nil {
-				m.Auth = &v1alpha2.AuthConfig{}
+			if m.Containerd == nil {
+				m.Containerd = &ContainerdInfo{}
 			}
-			if err := m.Auth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Containerd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipImages(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthImages
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImagePushResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImagePushResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImagePushResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -2760,79 +8874,69 @@ func (m *ImagePushResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipImages(dAtA[iNdEx:])
-			if err != nil {
+			if m.Runtime == nil {
+				m.Runtime = &RuntimeInfo{}
+			}
+			if err := m.Runtime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthImages
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Images", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImageProgressRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
+			if msglen < 0 {
+				return ErrInvalidLengthImages
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Images == nil {
+				m.Images = &ImagesInfo{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImageProgressRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageProgressRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.Images.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -2842,23 +8946,118 @@ func (m *ImageProgressRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
+			if m.Health == nil {
+				m.Health = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowImages
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipImages(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthImages
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Health[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2881,7 +9080,7 @@ func (m *ImageProgressRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImageProgressResponse) Unmarshal(dAtA []byte) error {
+func (m *BuildkitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2904,15 +9103,15 @@ func (m *ImageProgressResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImageProgressResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BuildkitInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageProgressResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BuildkitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Workers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2939,11 +9138,75 @@ func (m *ImageProgressResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = append(m.Status, ImageStatus{})
-			if err := m.Status[len(m.Status)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Workers = append(m.Workers, &BuildkitWorker{})
+			if err := m.Workers[len(m.Workers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipImages(dAtA[iNdEx:])
@@ -2965,7 +9228,7 @@ func (m *ImageProgressResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImageStatus) Unmarshal(dAtA []byte) error {
+func (m *BuildkitWorker) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2988,15 +9251,15 @@ func (m *ImageStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImageStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: BuildkitWorker: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BuildkitWorker: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3024,11 +9287,11 @@ func (m *ImageStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ref = string(dAtA[iNdEx:postIndex])
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Platforms", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3056,49 +9319,11 @@ func (m *ImageStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = string(dAtA[iNdEx:postIndex])
+			m.Platforms = append(m.Platforms, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
-			}
-			m.Offset = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowImages
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Offset |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
-			}
-			m.Total = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowImages
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Total |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3125,42 +9350,103 @@ func (m *ImageStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.StartedAt, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowImages
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowImages
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipImages(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthImages
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthImages
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthImages
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Labels[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3183,7 +9469,7 @@ func (m *ImageStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImageRemoveRequest) Unmarshal(dAtA []byte) error {
+func (m *ContainerdInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3206,17 +9492,17 @@ func (m *ImageRemoveRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImageRemoveRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContainerdInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageRemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContainerdInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -3226,78 +9512,88 @@ func (m *ImageRemoveRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.ImageSpec{}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
 			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipImages(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImageRemoveResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
+			m.Revision = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespaces", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImageRemoveResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageRemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespaces = append(m.Namespaces, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipImages(dAtA[iNdEx:])
@@ -3319,7 +9615,7 @@ func (m *ImageRemoveResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImageStatusRequest) Unmarshal(dAtA []byte) error {
+func (m *RuntimeInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3342,17 +9638,17 @@ func (m *ImageStatusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImageStatusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RuntimeInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RuntimeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -3362,81 +9658,91 @@ func (m *ImageStatusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.ImageSpec{}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipImages(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImageStatusResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApiVersion", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthImages
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImageStatusResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthImages
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ApiVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Conditions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3463,10 +9769,8 @@ func (m *ImageStatusResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.Image{}
-			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Conditions = append(m.Conditions, &v1alpha2.RuntimeCondition{})
+			if err := m.Conditions[len(m.Conditions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3491,7 +9795,7 @@ func (m *ImageStatusResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImageTagRequest) Unmarshal(dAtA []byte) error {
+func (m *ImagesInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3514,17 +9818,17 @@ func (m *ImageTagRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImageTagRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImagesInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageTagRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImagesInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -3534,33 +9838,67 @@ func (m *ImageTagRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.ImageSpec{}
-			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowImages
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Filesystems", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowImages
@@ -3570,77 +9908,29 @@ func (m *ImageTagRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthImages
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthImages
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipImages(dAtA[iNdEx:])
-			if err != nil {
+			m.Filesystems = append(m.Filesystems, &v1alpha2.FilesystemUsage{})
+			if err := m.Filesystems[len(m.Filesystems)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthImages
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImageTagResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowImages
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImageTagResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImageTagResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentSizes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3667,12 +9957,89 @@ func (m *ImageTagResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Image == nil {
-				m.Image = &v1alpha2.Image{}
-			}
-			if err := m.Image.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.ContentSizes == nil {
+				m.ContentSizes = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowImages
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthImages
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowImages
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipImages(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthImages
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
 			}
+			m.ContentSizes[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
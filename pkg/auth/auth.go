@@ -14,15 +14,9 @@ func Parse(auth *criv1.AuthConfig, host string) (string, string, error) {
 	if auth == nil {
 		return "", "", nil
 	}
-	if auth.ServerAddress != "" {
-		// Do not return the auth info when server address doesn't match.
-		u, err := url.Parse(auth.ServerAddress)
-		if err != nil {
-			return "", "", errors.Wrap(err, "parse server address")
-		}
-		if host != u.Host {
-			return "", "", nil
-		}
+	// Do not return the auth info when server address doesn't match.
+	if ok, err := Matches(auth, host); !ok || err != nil {
+		return "", "", err
 	}
 	if auth.Username != "" {
 		return auth.Username, auth.Password, nil
@@ -44,7 +38,43 @@ func Parse(auth *criv1.AuthConfig, host string) (string, string, error) {
 		user, passwd := fields[0], fields[1]
 		return user, strings.Trim(passwd, "\x00"), nil
 	}
-	// TODO(random-liu): Support RegistryToken.
+	// A registry token is not exchanged for credentials, see Token.
 	// An empty auth config is valid for anonymous registry
 	return "", "", nil
 }
+
+// Token returns the registry token of the AuthConfig, a bearer token sent as is to the registry.
+func Token(auth *criv1.AuthConfig, host string) (string, error) {
+	if auth == nil || auth.RegistryToken == "" {
+		return "", nil
+	}
+	if ok, err := Matches(auth, host); !ok || err != nil {
+		return "", err
+	}
+	return auth.RegistryToken, nil
+}
+
+// Matches returns whether the AuthConfig applies to the host, an AuthConfig without a server address applying to
+// all hosts. The server address may omit the scheme and the Docker Hub aliases are considered equivalent.
+func Matches(auth *criv1.AuthConfig, host string) (bool, error) {
+	if auth.ServerAddress == "" {
+		return true, nil
+	}
+	address := auth.ServerAddress
+	if !strings.Contains(address, "://") {
+		address = "https://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return false, errors.Wrap(err, "parse server address")
+	}
+	return normalizeHost(u.Host) == normalizeHost(host), nil
+}
+
+func normalizeHost(host string) string {
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
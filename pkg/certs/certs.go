@@ -0,0 +1,192 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const (
+	// CAFile is the name of the file (and secret key) of the certificate authority
+	CAFile = "ca.crt"
+	// CertFile is the name of the file (and secret key) of the certificate
+	CertFile = "tls.crt"
+	// KeyFile is the name of the file (and secret key) of the private key
+	KeyFile = "tls.key"
+
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+	// certs are reissued when they expire within this period
+	renewBefore = 90 * 24 * time.Hour
+)
+
+// NewCA returns the PEM encoded certificate and key of a self-signed certificate authority
+func NewCA(commonName string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour).UTC(),
+		NotAfter:              now.Add(caValidity).UTC(),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: der}), keyPEM, nil
+}
+
+// NewCert returns the PEM encoded certificate and key issued by the certificate authority for the config
+func NewCert(caPEM, caKeyPEM []byte, config certutil.Config) ([]byte, []byte, error) {
+	ca, caKey, err := parseCA(caPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   config.CommonName,
+			Organization: config.Organization,
+		},
+		DNSNames:    config.AltNames.DNSNames,
+		IPAddresses: config.AltNames.IPs,
+		NotBefore:   now.Add(-time.Hour).UTC(),
+		NotAfter:    now.Add(certValidity).UTC(),
+		KeyUsage:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: config.Usages,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateBlockType, Bytes: der}), keyPEM, nil
+}
+
+// Valid returns whether the certificate was issued by the certificate authority and does not expire soon
+func Valid(caPEM, certPEM []byte) bool {
+	if len(certPEM) == 0 {
+		return false
+	}
+	pool, err := caPool(caPEM)
+	if err != nil {
+		return false
+	}
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil {
+		return false
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:       pool,
+		CurrentTime: time.Now().Add(renewBefore),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+// ServerConfig returns the TLS configuration of a server requiring clients to present a certificate issued by the
+// certificate authority, loading the files from the directory.
+func ServerConfig(dir string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile))
+	if err != nil {
+		return nil, err
+	}
+	caPEM, err := ioutil.ReadFile(filepath.Join(dir, CAFile))
+	if err != nil {
+		return nil, err
+	}
+	pool, err := caPool(caPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientConfig returns the TLS configuration of a client presenting the certificate and verifying that of the server
+// against the certificate authority and server name.
+func ClientConfig(caPEM, certPEM, keyPEM []byte, serverName string) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := caPool(caPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func caPool(caPEM []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("no certificate authority found")
+	}
+	return pool, nil
+}
+
+func parseCA(caPEM, caKeyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	certs, err := certutil.ParseCertsPEM(caPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid certificate authority")
+	}
+	key, err := keyutil.ParsePrivateKeyPEM(caKeyPEM)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid certificate authority key")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("invalid certificate authority key")
+	}
+	return certs[0], signer, nil
+}
@@ -3,9 +3,14 @@ package cli
 import (
 	"github.com/rancher/k3c/pkg/cli/commands/agent"
 	"github.com/rancher/k3c/pkg/cli/commands/build"
+	"github.com/rancher/k3c/pkg/cli/commands/events"
+	"github.com/rancher/k3c/pkg/cli/commands/image"
 	"github.com/rancher/k3c/pkg/cli/commands/images"
 	"github.com/rancher/k3c/pkg/cli/commands/info"
 	"github.com/rancher/k3c/pkg/cli/commands/install"
+	"github.com/rancher/k3c/pkg/cli/commands/login"
+	"github.com/rancher/k3c/pkg/cli/commands/logout"
+	"github.com/rancher/k3c/pkg/cli/commands/preload"
 	"github.com/rancher/k3c/pkg/cli/commands/pull"
 	"github.com/rancher/k3c/pkg/cli/commands/push"
 	"github.com/rancher/k3c/pkg/cli/commands/rmi"
@@ -29,10 +34,15 @@ func Main() *cobra.Command {
 	root.AddCommand(
 		agent.Command(),
 		info.Command(),
+		image.Command(),
 		images.Command(),
 		install.Command(),
 		uninstall.Command(),
 		build.Command(),
+		events.Command(),
+		login.Command(),
+		logout.Command(),
+		preload.Command(),
 		pull.Command(),
 		push.Command(),
 		rmi.Command(),
@@ -1,24 +1,38 @@
 package agent
 
 import (
+	"github.com/rancher/k3c/pkg/cli/commands/agent/health"
+	"github.com/rancher/k3c/pkg/server"
 	"github.com/rancher/k3c/pkg/server/action"
 	wrangler "github.com/rancher/wrangler-cli"
 	"github.com/spf13/cobra"
 )
 
 func Command() *cobra.Command {
-	return wrangler.Command(&CommandSpec{}, cobra.Command{
+	cmd := wrangler.Command(&CommandSpec{}, cobra.Command{
 		Use:                   "agent [OPTIONS]",
 		Short:                 "Run the controller daemon",
 		Hidden:                true,
 		DisableFlagsInUseLine: true,
 	})
+	cmd.AddCommand(
+		health.Command(),
+	)
+	return cmd
 }
 
 type CommandSpec struct {
 	action.Agent
 }
 
+// Customize sets the defaults defined by the server rather than struct tags
+func (s *CommandSpec) Customize(cmd *cobra.Command) {
+	if flag := cmd.PersistentFlags().Lookup("sync-delete-policy"); flag != nil {
+		flag.DefValue = server.DefaultSyncDelete
+		_ = flag.Value.Set(flag.DefValue)
+	}
+}
+
 func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
 	return s.Agent.Run(cmd.Context())
 }
@@ -0,0 +1,24 @@
+package health
+
+import (
+	"github.com/rancher/k3c/pkg/server/action"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	return wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:                   "health [OPTIONS]",
+		Short:                 "Check the health of the controller daemon",
+		Hidden:                true,
+		DisableFlagsInUseLine: true,
+	})
+}
+
+type CommandSpec struct {
+	action.AgentHealth
+}
+
+func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
+	return s.AgentHealth.Run(cmd.Context())
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/client/action"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	return wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:                   "events [OPTIONS]",
+		Short:                 "Get real time image, content and build events from the builder",
+		DisableFlagsInUseLine: true,
+	})
+}
+
+type CommandSpec struct {
+	action.StreamEvents
+}
+
+func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
+	k8s, err := client.DefaultConfig.Interface()
+	if err != nil {
+		return err
+	}
+	return s.StreamEvents.Invoke(cmd.Context(), k8s)
+}
@@ -0,0 +1,31 @@
+package convert
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/client/action"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	return wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:   "convert [OPTIONS] SOURCE_IMAGE TARGET_IMAGE",
+		Short: "Convert an image to OCI media types and/or another layer compression",
+	})
+}
+
+type CommandSpec struct {
+	action.ConvertImage
+}
+
+func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("exactly two arguments are required")
+	}
+	k8s, err := client.DefaultConfig.Interface()
+	if err != nil {
+		return err
+	}
+	return s.ConvertImage.Invoke(cmd.Context(), k8s, args[0], args[1])
+}
@@ -0,0 +1,34 @@
+package copy
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/client/action"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	return wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:   "copy [OPTIONS] SOURCE_IMAGE [TARGET_IMAGE]",
+		Short: "Copy an image between containerd namespaces",
+	})
+}
+
+type CommandSpec struct {
+	action.CopyImage
+}
+
+func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("one or two arguments are required")
+	}
+	if len(args) == 1 {
+		args = append(args, "")
+	}
+	k8s, err := client.DefaultConfig.Interface()
+	if err != nil {
+		return err
+	}
+	return s.CopyImage.Invoke(cmd.Context(), k8s, args[0], args[1])
+}
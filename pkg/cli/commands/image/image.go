@@ -0,0 +1,28 @@
+package image
+
+import (
+	"github.com/rancher/k3c/pkg/cli/commands/image/convert"
+	"github.com/rancher/k3c/pkg/cli/commands/image/copy"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:                   "image",
+		Short:                 "Manage images",
+		DisableFlagsInUseLine: true,
+	})
+	cmd.AddCommand(
+		convert.Command(),
+		copy.Command(),
+	)
+	return cmd
+}
+
+type CommandSpec struct {
+}
+
+func (s *CommandSpec) Run(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
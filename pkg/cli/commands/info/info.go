@@ -1,7 +1,8 @@
 package info
 
 import (
-	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/client/action"
 	wrangler "github.com/rancher/wrangler-cli"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +15,13 @@ func Command() *cobra.Command {
 }
 
 type CommandSpec struct {
+	action.Info
 }
 
 func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
-	return errors.New("not implemented") // TODO
+	k8s, err := client.DefaultConfig.Interface()
+	if err != nil {
+		return err
+	}
+	return s.Info.Invoke(cmd.Context(), k8s)
 }
@@ -3,6 +3,7 @@ package install
 import (
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/rancher/k3c/pkg/client/action"
+	"github.com/rancher/k3c/pkg/server"
 	wrangler "github.com/rancher/wrangler-cli"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,19 @@ type CommandSpec struct {
 	action.InstallBuilder
 }
 
+// Customize defaults the builder to authorizing callers, which the agent itself only does when asked to, and the
+// defaults defined by the server rather than struct tags
+func (s *CommandSpec) Customize(cmd *cobra.Command) {
+	if flag := cmd.PersistentFlags().Lookup("authorization"); flag != nil {
+		flag.DefValue = "true"
+		_ = flag.Value.Set(flag.DefValue)
+	}
+	if flag := cmd.PersistentFlags().Lookup("sync-delete-policy"); flag != nil {
+		flag.DefValue = server.DefaultSyncDelete
+		_ = flag.Value.Set(flag.DefValue)
+	}
+}
+
 func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
 	k8s, err := client.DefaultConfig.Interface()
 	if err != nil {
@@ -30,11 +44,31 @@ func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	// assert service account
+	err = s.InstallBuilder.ServiceAccount(ctx, k8s)
+	if err != nil {
+		return err
+	}
+	// assert agent certificates
+	err = s.InstallBuilder.Certificates(ctx, k8s)
+	if err != nil {
+		return err
+	}
+	// assert sync rules
+	err = s.InstallBuilder.SyncRules(ctx, k8s)
+	if err != nil {
+		return err
+	}
 	// assert service
 	err = s.InstallBuilder.Service(ctx, k8s)
 	if err != nil {
 		return err
 	}
+	// assert metrics service
+	err = s.InstallBuilder.MetricsService(ctx, k8s)
+	if err != nil {
+		return err
+	}
 	// assert daemonset
 	err = s.InstallBuilder.DaemonSet(ctx, k8s)
 	if err != nil {
@@ -0,0 +1,35 @@
+package logout
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/client/action"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	return wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:   "logout [SERVER]",
+		Short: "Remove registry credentials from the cluster (default is Docker Hub)",
+	})
+}
+
+type CommandSpec struct {
+	action.LogoutRegistry
+}
+
+func (c *CommandSpec) Run(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return errors.New("at most one argument is allowed")
+	}
+	var server string
+	if len(args) == 1 {
+		server = args[0]
+	}
+	k8s, err := client.DefaultConfig.Interface()
+	if err != nil {
+		return err
+	}
+	return c.LogoutRegistry.Invoke(cmd.Context(), k8s, server)
+}
@@ -0,0 +1,28 @@
+package preload
+
+import (
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/client/action"
+	wrangler "github.com/rancher/wrangler-cli"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	return wrangler.Command(&CommandSpec{}, cobra.Command{
+		Use:                   "preload [OPTIONS]",
+		Short:                 "Pull the images referenced by Kubernetes manifests",
+		DisableFlagsInUseLine: true,
+	})
+}
+
+type CommandSpec struct {
+	action.PreloadImages
+}
+
+func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
+	k8s, err := client.DefaultConfig.Interface()
+	if err != nil {
+		return err
+	}
+	return s.PreloadImages.Invoke(cmd.Context(), k8s)
+}
@@ -1,7 +1,6 @@
 package pull
 
 import (
-	"github.com/pkg/errors"
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/rancher/k3c/pkg/client/action"
 	wrangler "github.com/rancher/wrangler-cli"
@@ -10,8 +9,8 @@ import (
 
 func Command() *cobra.Command {
 	return wrangler.Command(&CommandSpec{}, cobra.Command{
-		Use:   "pull [OPTIONS] IMAGE",
-		Short: "Pull an image",
+		Use:   "pull [OPTIONS] IMAGE [IMAGE...]",
+		Short: "Pull one or more images",
 	})
 }
 
@@ -20,13 +19,9 @@ type CommandSpec struct {
 }
 
 func (s *CommandSpec) Run(cmd *cobra.Command, args []string) error {
-	if len(args) != 1 {
-		return errors.New("exactly one argument is required")
-	}
-
 	k8s, err := client.DefaultConfig.Interface()
 	if err != nil {
 		return err
 	}
-	return s.PullImage.Invoke(cmd.Context(), k8s, args[0])
+	return s.PullImage.Invoke(cmd.Context(), k8s, args)
 }
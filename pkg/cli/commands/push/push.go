@@ -10,8 +10,8 @@ import (
 
 func Command() *cobra.Command {
 	return wrangler.Command(&CommandSpec{}, cobra.Command{
-		Use:   "push [OPTIONS] IMAGE",
-		Short: "Push an image",
+		Use:   "push [OPTIONS] IMAGE|REPOSITORY",
+		Short: "Push an image or a repository",
 	})
 }
 
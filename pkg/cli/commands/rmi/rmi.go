@@ -10,8 +10,8 @@ import (
 
 func Command() *cobra.Command {
 	return wrangler.Command(&CommandSpec{}, cobra.Command{
-		Use:   "rmi REF",
-		Short: "Remove an image",
+		Use:   "rmi [OPTIONS] IMAGE [IMAGE...]",
+		Short: "Remove one or more images",
 	})
 }
 
@@ -20,13 +20,13 @@ type CommandSpec struct {
 }
 
 func (c *CommandSpec) Run(cmd *cobra.Command, args []string) error {
-	if len(args) != 1 {
-		return errors.New("exactly one argument is required")
+	if len(args) < 1 {
+		return errors.New("at least one argument is required")
 	}
 
 	k8s, err := client.DefaultConfig.Interface()
 	if err != nil {
 		return err
 	}
-	return c.RemoveImage.Invoke(cmd.Context(), k8s, args[0])
+	return c.RemoveImage.Invoke(cmd.Context(), k8s, args)
 }
@@ -0,0 +1,36 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/client"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+type ConvertImage struct {
+	Compression      string `usage:"Compress layers with gzip, zstd or estargz (default is as stored)"`
+	ForceCompression bool   `usage:"Recompress layers already compressed as requested"`
+	OCI              bool   `usage:"Convert docker media types to OCI"`
+}
+
+func (s *ConvertImage) Invoke(ctx context.Context, k8s *client.Interface, image, target string) error {
+	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		req := &imagesv1.ImageConvertRequest{
+			Image: &criv1.ImageSpec{
+				Image: image,
+			},
+			Target:           target,
+			Oci:              s.OCI,
+			Compression:      s.Compression,
+			ForceCompression: s.ForceCompression,
+		}
+		res, err := imagesClient.Convert(ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Println(res.Image)
+		return nil
+	})
+}
@@ -0,0 +1,34 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/client"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+type CopyImage struct {
+	FromNamespace string `usage:"Containerd namespace of the source image (default is the image namespace of the agent, k8s.io)"`
+	ToNamespace   string `usage:"Containerd namespace of the copied image (default is the image namespace of the agent, k8s.io)"`
+}
+
+func (s *CopyImage) Invoke(ctx context.Context, k8s *client.Interface, image, target string) error {
+	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		req := &imagesv1.ImageCopyRequest{
+			Image: &criv1.ImageSpec{
+				Image: image,
+			},
+			Target:        target,
+			FromNamespace: s.FromNamespace,
+			ToNamespace:   s.ToNamespace,
+		}
+		res, err := imagesClient.Copy(ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Println(res.Image)
+		return nil
+	})
+}
@@ -0,0 +1,88 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/client"
+)
+
+type StreamEvents struct {
+	Filter []string `usage:"Filter output based on conditions provided (type=image|content|build, namespace=NAME, name=NAME)" short:"f"`
+	Since  string   `usage:"Show events created since timestamp (e.g. 2021-01-02T13:23:37Z) or relative duration (e.g. 42m)"`
+	Output string   `usage:"Output format (json)" short:"o"`
+}
+
+func (s *StreamEvents) Invoke(ctx context.Context, k8s *client.Interface) error {
+	req := &imagesv1.ImageEventsRequest{
+		Filters: s.Filter,
+	}
+	if s.Since != "" {
+		since, err := parseSince(s.Since)
+		if err != nil {
+			return err
+		}
+		req.Since = &since
+	}
+	switch s.Output {
+	case "", "json":
+	default:
+		return errors.Errorf("unsupported output format %q", s.Output)
+	}
+	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		evc, err := imagesClient.Events(ctx, req)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		for {
+			evt, err := evc.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if s.Output == "json" {
+				if err := enc.Encode(evt); err != nil {
+					return err
+				}
+				continue
+			}
+			fmt.Println(formatEvent(evt))
+		}
+	})
+}
+
+// formatEvent renders the event in the style of `docker events`
+func formatEvent(evt *imagesv1.ImageEventsResponse) string {
+	attrs := []string{fmt.Sprintf("namespace=%s", evt.Namespace)}
+	var keys []string
+	for k := range evt.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs = append(attrs, fmt.Sprintf("%s=%s", k, evt.Attributes[k]))
+	}
+	return fmt.Sprintf("%s %s %s %s (%s)", evt.Timestamp.Local().Format(time.RFC3339Nano), evt.Type, evt.Action, evt.Name, strings.Join(attrs, ", "))
+}
+
+func parseSince(since string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, since); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid since %q, expected a timestamp or duration", since)
+	}
+	return time.Now().Add(-d), nil
+}
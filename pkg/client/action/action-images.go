@@ -26,7 +26,6 @@ type ListImages struct {
 func (s *ListImages) Invoke(ctx context.Context, k8s *client.Interface, names []string) error {
 	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
 		req := &imagesv1.ImageListRequest{}
-		// TODO filtering not working as expected
 		if len(names) > 0 {
 			req.Filter = &criv1.ImageFilter{
 				Image: &criv1.ImageSpec{
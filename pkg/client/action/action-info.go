@@ -0,0 +1,221 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/version"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Info struct {
+	Output string `usage:"Output format (json)" short:"o"`
+}
+
+type infoOutput struct {
+	Client  versionInfo            `json:"client"`
+	Builder builderInfo            `json:"builder"`
+	Agent   *imagesv1.InfoResponse `json:"agent"`
+}
+
+type versionInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+}
+
+type builderInfo struct {
+	Namespace     string   `json:"namespace"`
+	Nodes         []string `json:"nodes"`
+	AgentImage    string   `json:"agentImage,omitempty"`
+	BuildkitImage string   `json:"buildkitImage,omitempty"`
+}
+
+func (s *Info) Invoke(ctx context.Context, k8s *client.Interface) error {
+	switch s.Output {
+	case "", "json":
+	default:
+		return errors.Errorf("unsupported output format %q", s.Output)
+	}
+	builder, err := getBuilderInfo(k8s)
+	if err != nil {
+		return err
+	}
+	out := infoOutput{
+		Client: versionInfo{
+			Version:   version.Version,
+			GitCommit: version.GitCommit,
+		},
+		Builder: builder,
+	}
+	err = DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		if !agentCapable(ctx, version.CapabilityInfo) {
+			logrus.Warnf("the agent does not support info, upgrade it with `k3c install`")
+			return nil
+		}
+		out.Agent, err = imagesClient.Info(ctx, &imagesv1.InfoRequest{})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if s.Output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	printInfo(out)
+	return nil
+}
+
+// getBuilderInfo returns the nodes of the builder service and the images of the builder daemonset
+func getBuilderInfo(k8s *client.Interface) (builderInfo, error) {
+	info := builderInfo{Namespace: k8s.Namespace}
+	endpoints, err := k8s.Core.Endpoints().Get(k8s.Namespace, "builder", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return info, errors.Errorf("builder not found in namespace %s, see `k3c install`", k8s.Namespace)
+	}
+	if err != nil {
+		return info, err
+	}
+	for _, sub := range endpoints.Subsets {
+		for _, addr := range sub.Addresses {
+			if addr.NodeName != nil {
+				info.Nodes = append(info.Nodes, *addr.NodeName)
+			}
+		}
+	}
+	sort.Strings(info.Nodes)
+	daemon, err := k8s.Apps.DaemonSet().Get(k8s.Namespace, "builder", metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return info, err
+	}
+	if err == nil {
+		for _, container := range daemon.Spec.Template.Spec.Containers {
+			switch container.Name {
+			case "agent":
+				info.AgentImage = container.Image
+			case "buildkit":
+				info.BuildkitImage = container.Image
+			}
+		}
+	}
+	return info, nil
+}
+
+// printInfo renders the info in the style of `docker info`
+func printInfo(out infoOutput) {
+	fmt.Println("Client:")
+	fmt.Printf(" Version: %s\n", out.Client.Version)
+	fmt.Printf(" Git Commit: %s\n", out.Client.GitCommit)
+	fmt.Println()
+	fmt.Println("Builder:")
+	fmt.Printf(" Namespace: %s\n", out.Builder.Namespace)
+	fmt.Printf(" Nodes: %s\n", strings.Join(out.Builder.Nodes, ", "))
+	fmt.Printf(" Agent Image: %s\n", out.Builder.AgentImage)
+	fmt.Printf(" BuildKit Image: %s\n", out.Builder.BuildkitImage)
+	fmt.Println()
+
+	agent := out.Agent
+	fmt.Println("Agent:")
+	if agent == nil {
+		fmt.Println(" unavailable")
+		return
+	}
+	fmt.Printf(" Version: %s\n", agent.Version)
+	fmt.Printf(" Git Commit: %s\n", agent.GitCommit)
+	fmt.Printf(" Node: %s\n", agent.Node)
+	fmt.Println(" Health:")
+	var services []string
+	for service := range agent.Health {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		fmt.Printf("  %s: %s\n", service, agent.Health[service])
+	}
+	fmt.Println()
+
+	fmt.Println("BuildKit:")
+	if agent.Buildkit == nil {
+		fmt.Println(" unavailable")
+	} else {
+		fmt.Printf(" Version: %s\n", agent.Buildkit.Version)
+		fmt.Printf(" Image: %s\n", agent.Buildkit.Image)
+		fmt.Println(" Workers:")
+		for _, worker := range agent.Buildkit.Workers {
+			fmt.Printf("  %s:\n", worker.Id)
+			fmt.Printf("   Platforms: %s\n", strings.Join(worker.Platforms, ", "))
+			fmt.Printf("   Labels: %s\n", joinMap(worker.Labels))
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("Containerd:")
+	if agent.Containerd == nil {
+		fmt.Println(" unavailable")
+	} else {
+		fmt.Printf(" Version: %s (%s)\n", agent.Containerd.Version, agent.Containerd.Revision)
+		fmt.Printf(" Namespaces: %s\n", strings.Join(agent.Containerd.Namespaces, ", "))
+	}
+	fmt.Println()
+
+	fmt.Println("Runtime:")
+	if agent.Runtime == nil {
+		fmt.Println(" unavailable")
+	} else {
+		fmt.Printf(" Name: %s\n", agent.Runtime.Name)
+		fmt.Printf(" Version: %s\n", agent.Runtime.Version)
+		fmt.Printf(" API Version: %s\n", agent.Runtime.ApiVersion)
+		fmt.Println(" Conditions:")
+		for _, condition := range agent.Runtime.Conditions {
+			fmt.Printf("  %s: %t", condition.Type, condition.Status)
+			if condition.Reason != "" || condition.Message != "" {
+				fmt.Printf(" (%s %s)", condition.Reason, condition.Message)
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("Images:")
+	if agent.Images == nil {
+		fmt.Println(" unavailable")
+		return
+	}
+	fmt.Printf(" Namespace: %s\n", agent.Images.Namespace)
+	fmt.Printf(" Count: %d\n", agent.Images.Count)
+	fmt.Printf(" Size: %s\n", units.HumanSize(float64(agent.Images.Size_)))
+	for _, fs := range agent.Images.Filesystems {
+		fmt.Printf(" Filesystem: %s\n", fs.GetFsId().GetMountpoint())
+		fmt.Printf("  Used: %s\n", units.HumanSize(float64(fs.GetUsedBytes().GetValue())))
+		fmt.Printf("  Inodes Used: %d\n", fs.GetInodesUsed().GetValue())
+	}
+	fmt.Println(" Content:")
+	var nss []string
+	for ns := range agent.Images.ContentSizes {
+		nss = append(nss, ns)
+	}
+	sort.Strings(nss)
+	for _, ns := range nss {
+		fmt.Printf("  %s: %s\n", ns, units.HumanSize(float64(agent.Images.ContentSizes[ns])))
+	}
+}
+
+func joinMap(m map[string]string) string {
+	var pairs []string
+	for k, v := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -1,20 +1,40 @@
 package action
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/certs"
 	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/registries"
 	"github.com/rancher/k3c/pkg/server"
+	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	certutil "k8s.io/client-go/util/cert"
 	"k8s.io/client-go/util/retry"
 )
 
+const (
+	// syncRulesConfigMap is the config map of the sync rules, mounted by the agent in the syncRulesDir
+	syncRulesConfigMap = "builder-sync"
+	syncRulesDir       = "/etc/rancher/k3c/sync"
+	syncRulesKey       = "rules.yaml"
+	// syncRulesChecksumAnnotation annotates the builder pods with the checksum of the sync rules, which are only read
+	// at startup, so that installing other rules rolls the builders
+	syncRulesChecksumAnnotation = "k3c.cattle.io/sync-rules-checksum"
+)
+
 type InstallBuilder struct {
 	Force    bool   `usage:"Force installation by deleting existing builder"`
 	Selector string `usage:"Selector for nodes (label query) to apply builder role"`
@@ -47,6 +67,259 @@ func (_ *InstallBuilder) Namespace(_ context.Context, k *client.Interface) error
 	})
 }
 
+// ServiceAccount asserts the service account of the builder along with the role granting it read access to the
+// registry credentials stored via `k3c login`, to the service accounts of the k3c namespace and their imagePullSecrets
+// and to its pods. With --authorization the cluster role grants it the reviews of the tokens and access of callers, the
+// service accounts of other namespaces being read with the token of the caller.
+func (a *InstallBuilder) ServiceAccount(ctx context.Context, k *client.Interface) error {
+	meta := metav1.ObjectMeta{
+		Name:      "builder",
+		Namespace: k.Namespace,
+		Labels: labels.Set{
+			"app.kubernetes.io/managed-by": "k3c",
+		},
+	}
+	if _, err := k.Core.ServiceAccount().Get(k.Namespace, meta.Name, metav1.GetOptions{}); apierr.IsNotFound(err) {
+		if _, err = k.Core.ServiceAccount().Create(&corev1.ServiceAccount{ObjectMeta: meta}); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	rules := []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"serviceaccounts", "secrets"},
+		Verbs:     []string{"get"},
+	}, {
+		// the pods of the builder, for the version of buildkit
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get"},
+	}}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		role, err := k.Rbac.Role().Get(k.Namespace, meta.Name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			_, err = k.Rbac.Role().Create(&rbacv1.Role{ObjectMeta: meta, Rules: rules})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		role.Rules = rules
+		_, err = k.Rbac.Role().Update(role)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      meta.Name,
+		Namespace: k.Namespace,
+	}}
+	_, err = k.Rbac.RoleBinding().Get(k.Namespace, meta.Name, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		_, err = k.Rbac.RoleBinding().Create(&rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     meta.Name,
+			},
+			Subjects: subjects,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	clusterMeta := metav1.ObjectMeta{
+		Name:   builderClusterRole(k),
+		Labels: meta.Labels,
+	}
+	if !a.Authorization {
+		// the cluster role of a previous installation with --authorization is removed
+		return (&UninstallBuilder{}).ClusterRole(ctx, k)
+	}
+	clusterRules := []rbacv1.PolicyRule{{
+		APIGroups: []string{"authentication.k8s.io"},
+		Resources: []string{"tokenreviews"},
+		Verbs:     []string{"create"},
+	}, {
+		APIGroups: []string{"authorization.k8s.io"},
+		Resources: []string{"subjectaccessreviews"},
+		Verbs:     []string{"create"},
+	}}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		role, err := k.Rbac.ClusterRole().Get(clusterMeta.Name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			_, err = k.Rbac.ClusterRole().Create(&rbacv1.ClusterRole{ObjectMeta: clusterMeta, Rules: clusterRules})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		role.Rules = clusterRules
+		_, err = k.Rbac.ClusterRole().Update(role)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = k.Rbac.ClusterRoleBinding().Get(clusterMeta.Name, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		_, err = k.Rbac.ClusterRoleBinding().Create(&rbacv1.ClusterRoleBinding{
+			ObjectMeta: clusterMeta,
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     clusterMeta.Name,
+			},
+			Subjects: subjects,
+		})
+	}
+	return err
+}
+
+// builderClusterRole is the name of the cluster role (and binding) of the builder, qualified by namespace as builders
+// may be installed in several namespaces.
+func builderClusterRole(k *client.Interface) string {
+	return fmt.Sprintf("k3c-%s-builder", k.Namespace)
+}
+
+// Certificates asserts the certificate authority of the agent along with the server certificate mounted by the builder
+// and the client certificate presented by the cli, stored as secrets. Certificates are (re)issued when missing,
+// expiring soon or not issued by the certificate authority.
+func (_ *InstallBuilder) Certificates(_ context.Context, k *client.Interface) error {
+	ca, err := k.Core.Secret().Get(k.Namespace, client.AgentCASecret, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		caPEM, caKeyPEM, err := certs.NewCA(fmt.Sprintf("k3c-%s-ca", k.Namespace))
+		if err != nil {
+			return err
+		}
+		ca, err = k.Core.Secret().Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      client.AgentCASecret,
+				Namespace: k.Namespace,
+				Labels: labels.Set{
+					"app.kubernetes.io/managed-by": "k3c",
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				certs.CertFile: caPEM,
+				certs.KeyFile:  caKeyPEM,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	caPEM, caKeyPEM := ca.Data[certs.CertFile], ca.Data[certs.KeyFile]
+	err = assertCertificate(k, client.AgentServerSecret, caPEM, caKeyPEM, certutil.Config{
+		CommonName: "builder",
+		AltNames: certutil.AltNames{
+			DNSNames: []string{"builder", "builder." + k.Namespace, k.AgentServerName()},
+		},
+		Usages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	if err != nil {
+		return err
+	}
+	return assertCertificate(k, client.AgentClientSecret, caPEM, caKeyPEM, certutil.Config{
+		CommonName: "k3c",
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+}
+
+// SyncRules asserts the config map of the sync rules mounted by the builder, read from the local file of the rules
+// once validated. Without rules the config map is removed, the agent syncing the buildkit namespace to the image
+// namespace.
+func (a *InstallBuilder) SyncRules(_ context.Context, k *client.Interface) error {
+	if a.SyncRulesFile == "" {
+		err := k.Core.ConfigMap().Delete(k.Namespace, syncRulesConfigMap, &metav1.DeleteOptions{})
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err := server.LoadSyncRules(a.SyncRulesFile, server.SyncRule{
+		From:         a.BuildkitNamespace,
+		To:           []string{a.ImageNamespace},
+		DeletePolicy: a.SyncDeletePolicy,
+	})
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(a.SyncRulesFile)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := k.Core.ConfigMap().Get(k.Namespace, syncRulesConfigMap, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			_, err = k.Core.ConfigMap().Create(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      syncRulesConfigMap,
+					Namespace: k.Namespace,
+					Labels: labels.Set{
+						"app.kubernetes.io/managed-by": "k3c",
+					},
+				},
+				Data: map[string]string{syncRulesKey: string(data)},
+			})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		cm.Data = map[string]string{syncRulesKey: string(data)}
+		_, err = k.Core.ConfigMap().Update(cm)
+		return err
+	})
+}
+
+func assertCertificate(k *client.Interface, name string, caPEM, caKeyPEM []byte, config certutil.Config) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := k.Core.Secret().Get(k.Namespace, name, metav1.GetOptions{})
+		exists := err == nil
+		if err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+		if exists && bytes.Equal(secret.Data[certs.CAFile], caPEM) && certs.Valid(caPEM, secret.Data[certs.CertFile]) {
+			return nil
+		}
+		certPEM, keyPEM, err := certs.NewCert(caPEM, caKeyPEM, config)
+		if err != nil {
+			return err
+		}
+		data := map[string][]byte{
+			certs.CAFile:   caPEM,
+			certs.CertFile: certPEM,
+			certs.KeyFile:  keyPEM,
+		}
+		if !exists {
+			_, err = k.Core.Secret().Create(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: k.Namespace,
+					Labels: labels.Set{
+						"app.kubernetes.io/managed-by": "k3c",
+					},
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: data,
+			})
+			return err
+		}
+		secret.Data = data
+		_, err = k.Core.Secret().Update(secret)
+		return err
+	})
+}
+
 func (a *InstallBuilder) containerPort(name string) corev1.ContainerPort {
 	switch name {
 	case "buildkit":
@@ -61,11 +334,27 @@ func (a *InstallBuilder) containerPort(name string) corev1.ContainerPort {
 			ContainerPort: int32(a.AgentPort),
 			Protocol:      corev1.ProtocolTCP,
 		}
+	case "metrics":
+		return corev1.ContainerPort{
+			Name:          name,
+			ContainerPort: int32(a.MetricsPort),
+			Protocol:      corev1.ProtocolTCP,
+		}
 	default:
 		return corev1.ContainerPort{Name: name}
 	}
 }
 
+func (a *InstallBuilder) agentContainerPorts() []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{
+		a.containerPort("k3c"),
+	}
+	if a.MetricsPort > 0 {
+		ports = append(ports, a.containerPort("metrics"))
+	}
+	return ports
+}
+
 func (a *InstallBuilder) servicePort(name string) corev1.ServicePort {
 	switch name {
 	case "buildkit":
@@ -80,11 +369,37 @@ func (a *InstallBuilder) servicePort(name string) corev1.ServicePort {
 			Port:     int32(a.AgentPort),
 			Protocol: corev1.ProtocolTCP,
 		}
+	case "metrics":
+		return corev1.ServicePort{
+			Name:     name,
+			Port:     int32(a.MetricsPort),
+			Protocol: corev1.ProtocolTCP,
+		}
 	default:
 		return corev1.ServicePort{Name: name}
 	}
 }
 
+// servicePorts returns the ports of the builder service, the metrics being exposed by the metrics service only
+func (a *InstallBuilder) servicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		a.servicePort("buildkit"),
+		a.servicePort("k3c"),
+	}
+}
+
+// mergeServicePorts returns the desired ports, keeping the node ports allocated to existing ports of the same name
+func mergeServicePorts(existing, desired []corev1.ServicePort) []corev1.ServicePort {
+	for i := range desired {
+		for _, port := range existing {
+			if port.Name == desired[i].Name && port.Port == desired[i].Port {
+				desired[i].NodePort = port.NodePort
+			}
+		}
+	}
+	return desired
+}
+
 func (a *InstallBuilder) Service(_ context.Context, k *client.Interface) error {
 	if a.Force {
 		deletePropagation := metav1.DeletePropagationBackground
@@ -113,10 +428,7 @@ func (a *InstallBuilder) Service(_ context.Context, k *client.Interface) error {
 						"app.kubernetes.io/name":      "k3c",
 						"app.kubernetes.io/component": "builder",
 					},
-					Ports: []corev1.ServicePort{
-						a.servicePort("buildkit"),
-						a.servicePort("k3c"),
-					},
+					Ports: a.servicePorts(),
 				},
 			}
 			svc, err = k.Core.Service().Create(svc)
@@ -128,11 +440,54 @@ func (a *InstallBuilder) Service(_ context.Context, k *client.Interface) error {
 		if _, ok := svc.Labels["app.kubernetes.io/managed-by"]; !ok {
 			svc.Labels["app.kubernetes.io/managed-by"] = "k3c"
 		}
+		svc.Spec.Ports = mergeServicePorts(svc.Spec.Ports, a.servicePorts())
 		svc, err = k.Core.Service().Update(svc)
 		return err
 	})
 }
 
+// MetricsService asserts the cluster-internal service of the metrics port, named so that a ServiceMonitor can select
+// it, rather than exposing the metrics on the node ports of the builder service. The service is removed when metrics
+// are disabled.
+func (a *InstallBuilder) MetricsService(_ context.Context, k *client.Interface) error {
+	if a.MetricsPort <= 0 {
+		if err := k.Core.Service().Delete(k.Namespace, "builder-metrics", &metav1.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		svc, err := k.Core.Service().Get(k.Namespace, "builder-metrics", metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			svc = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "builder-metrics",
+					Namespace: k.Namespace,
+					Labels: labels.Set{
+						"app.kubernetes.io/managed-by": "k3c",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeClusterIP,
+					Selector: labels.Set{
+						"app.kubernetes.io/name":      "k3c",
+						"app.kubernetes.io/component": "builder",
+					},
+					Ports: []corev1.ServicePort{a.servicePort("metrics")},
+				},
+			}
+			_, err = k.Core.Service().Create(svc)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		svc.Spec.Ports = []corev1.ServicePort{a.servicePort("metrics")}
+		_, err = k.Core.Service().Update(svc)
+		return err
+	})
+}
+
 func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error {
 	if a.Force {
 		deletePropagation := metav1.DeletePropagationBackground
@@ -141,10 +496,62 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 		}
 		k.Apps.DaemonSet().Delete(k.Namespace, "builder", &deleteOptions)
 	}
+	if a.RegistriesFile == "" {
+		a.RegistriesFile = server.DefaultRegistries
+	}
+	if a.AgentTLSDir == "" {
+		a.AgentTLSDir = server.DefaultAgentTLSDir
+	}
+	if a.HealthPort <= 0 {
+		a.HealthPort = server.DefaultHealthPort
+	}
+	if a.ImageNamespace == "" {
+		a.ImageNamespace = server.DefaultImageNs
+	}
+	if a.ResyncInterval == "" {
+		a.ResyncInterval = server.DefaultResync
+	}
+	if a.IngestPollInterval == "" {
+		a.IngestPollInterval = server.DefaultIngestPoll
+	}
+	if a.SyncDeletePolicy == "" {
+		a.SyncDeletePolicy = server.DefaultSyncDelete
+	}
+	var annotations map[string]string
+	if a.SyncRulesFile != "" {
+		data, err := ioutil.ReadFile(a.SyncRulesFile)
+		if err != nil {
+			return err
+		}
+		annotations = map[string]string{syncRulesChecksumAnnotation: fmt.Sprintf("%x", sha256.Sum256(data))}
+	}
 	privileged := true
 	hostPathDirectory := corev1.HostPathDirectory
 	hostPathDirectoryOrCreate := corev1.HostPathDirectoryOrCreate
+	hostPathFile := corev1.HostPathFile
+	hostPathFileOrCreate := corev1.HostPathFileOrCreate
 	mountPropagationBidirectional := corev1.MountPropagationBidirectional
+	// the agent is live, and ready, as long as it serves: the health of its backends is reported by `k3c info`
+	agentLivenessProbe := corev1.Probe{
+		Handler: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"k3c", "agent", "health", fmt.Sprintf("--port=%d", a.HealthPort), fmt.Sprintf("--service=%s", server.HealthServiceImages)},
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       20,
+		TimeoutSeconds:      10,
+	}
+	agentReadinessProbe := corev1.Probe{
+		Handler: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"k3c", "agent", "health", fmt.Sprintf("--port=%d", a.HealthPort), fmt.Sprintf("--service=%s", server.HealthServiceImages)},
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      10,
+	}
 	containerProbe := corev1.Probe{
 		Handler: corev1.Handler{
 			Exec: &corev1.ExecAction{
@@ -174,6 +581,7 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
 					Labels: labels.Set{
 						"app":                          "k3c",
 						"component":                    "builder",
@@ -183,9 +591,10 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 					},
 				},
 				Spec: corev1.PodSpec{
-					HostNetwork: true,
-					HostPID:     true,
-					HostIPC:     true,
+					ServiceAccountName: "builder",
+					HostNetwork:        true,
+					HostPID:            true,
+					HostIPC:            true,
 					NodeSelector: labels.Set{
 						"node-role.kubernetes.io/builder": "true",
 					},
@@ -196,6 +605,10 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 						Args: []string{
 							fmt.Sprintf("--addr=%s", a.BuildkitSocket),
 							fmt.Sprintf("--addr=tcp://0.0.0.0:%d", a.BuildkitPort),
+							// the buildkit port requires the client certificate of the agent, as the agent port does
+							fmt.Sprintf("--tlscacert=%s", filepath.Join(a.AgentTLSDir, certs.CAFile)),
+							fmt.Sprintf("--tlscert=%s", filepath.Join(a.AgentTLSDir, certs.CertFile)),
+							fmt.Sprintf("--tlskey=%s", filepath.Join(a.AgentTLSDir, certs.KeyFile)),
 							"--containerd-worker=true",
 							fmt.Sprintf("--containerd-worker-addr=%s", a.ContainerdSocket),
 							"--containerd-worker-gc",
@@ -210,6 +623,7 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 						VolumeMounts: []corev1.VolumeMount{
 							{Name: "cgroup", MountPath: "/sys/fs/cgroup"},
 							{Name: "run", MountPath: "/run", MountPropagation: &mountPropagationBidirectional},
+							{Name: "tls", MountPath: a.AgentTLSDir, ReadOnly: true},
 							{Name: "tmp", MountPath: "/tmp", MountPropagation: &mountPropagationBidirectional},
 							{Name: "var-lib-buildkit", MountPath: "/var/lib/buildkit", MountPropagation: &mountPropagationBidirectional},
 							{Name: "var-lib-rancher", MountPath: "/var/lib/rancher", MountPropagation: &mountPropagationBidirectional},
@@ -222,19 +636,56 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 						Command: []string{"k3c", "--debug", "agent"},
 						Args: []string{
 							fmt.Sprintf("--agent-port=%d", a.AgentPort),
+							fmt.Sprintf("--agent-tls-dir=%s", a.AgentTLSDir),
+							fmt.Sprintf("--authorization=%t", a.Authorization),
 							fmt.Sprintf("--buildkit-socket=%s", a.BuildkitSocket),
 							fmt.Sprintf("--buildkit-port=%d", a.BuildkitPort),
 							fmt.Sprintf("--containerd-socket=%s", a.ContainerdSocket),
+							fmt.Sprintf("--health-port=%d", a.HealthPort),
+							fmt.Sprintf("--image-namespace=%s", a.ImageNamespace),
+							fmt.Sprintf("--ingest-poll-interval=%s", a.IngestPollInterval),
+							fmt.Sprintf("--limit-rate=%s", a.LimitRate),
+							// the node address (of the host network) rather than all of its interfaces, for the service
+							"--metrics-address=$(POD_IP)",
+							fmt.Sprintf("--metrics-port=%d", a.MetricsPort),
+							fmt.Sprintf("--registries-file=%s", a.RegistriesFile),
+							fmt.Sprintf("--resync-interval=%s", a.ResyncInterval),
+							fmt.Sprintf("--retries=%d", a.Retries),
+							fmt.Sprintf("--snapshotter=%s", a.Snapshotter),
+							fmt.Sprintf("--sync-delete-policy=%s", a.SyncDeletePolicy),
 						},
-						Ports: []corev1.ContainerPort{
-							a.containerPort("k3c"),
-						},
+						Env: []corev1.EnvVar{{
+							Name: "NAMESPACE",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+							},
+						}, {
+							Name: "NODE_NAME",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+							},
+						}, {
+							Name: "POD_NAME",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+							},
+						}, {
+							Name: "POD_IP",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+							},
+						}},
+						Ports:          a.agentContainerPorts(),
+						ReadinessProbe: &agentReadinessProbe,
+						LivenessProbe:  &agentLivenessProbe,
 						SecurityContext: &corev1.SecurityContext{
 							Privileged: &privileged,
 						},
 						VolumeMounts: []corev1.VolumeMount{
 							{Name: "etc-pki", MountPath: "/etc/pki", ReadOnly: true},
 							{Name: "etc-ssl", MountPath: "/etc/ssl", ReadOnly: true},
+							{Name: "registries", MountPath: a.RegistriesFile, ReadOnly: true},
+							{Name: "tls", MountPath: a.AgentTLSDir, ReadOnly: true},
 							{Name: "run", MountPath: "/run", MountPropagation: &mountPropagationBidirectional},
 							{Name: "var-lib-rancher", MountPath: "/var/lib/rancher", MountPropagation: &mountPropagationBidirectional},
 						},
@@ -254,6 +705,20 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 								},
 							},
 						},
+						{
+							Name: "registries", VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: a.RegistriesFile, Type: &hostPathFileOrCreate,
+								},
+							},
+						},
+						{
+							Name: "tls", VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: client.AgentServerSecret,
+								},
+							},
+						},
 						{
 							Name: "cgroup", VolumeSource: corev1.VolumeSource{
 								HostPath: &corev1.HostPathVolumeSource{
@@ -294,13 +759,87 @@ func (a *InstallBuilder) DaemonSet(_ context.Context, k *client.Interface) error
 			},
 		},
 	}
-	_, err := k.Apps.DaemonSet().Create(daemon)
+	// only the registries configuration and the TLS files it references are mounted, not the k3s configuration (and
+	// credentials) alongside it. The TLS files are those referenced by the configuration of this host, if any.
+	registry, err := registries.Load(a.RegistriesFile)
+	if err != nil {
+		logrus.Warnf("failed to load %s, not mounting its TLS files: %v", a.RegistriesFile, err)
+		registry = &registries.Registry{}
+	}
+	for n, file := range registry.Files() {
+		spec := &daemon.Spec.Template.Spec
+		name := fmt.Sprintf("registries-tls-%d", n)
+		for i := range spec.Containers {
+			if agent := &spec.Containers[i]; agent.Name == "agent" {
+				agent.VolumeMounts = append(agent.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: file, ReadOnly: true})
+			}
+		}
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: name, VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: file, Type: &hostPathFile,
+				},
+			},
+		})
+	}
+	if a.SyncRulesFile != "" {
+		spec := &daemon.Spec.Template.Spec
+		for i := range spec.Containers {
+			if agent := &spec.Containers[i]; agent.Name == "agent" {
+				agent.Args = append(agent.Args, fmt.Sprintf("--sync-rules-file=%s", filepath.Join(syncRulesDir, syncRulesKey)))
+				agent.VolumeMounts = append(agent.VolumeMounts, corev1.VolumeMount{Name: "sync", MountPath: syncRulesDir, ReadOnly: true})
+			}
+		}
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: "sync", VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: syncRulesConfigMap},
+				},
+			},
+		})
+	}
+	_, err = k.Apps.DaemonSet().Create(daemon)
 	if apierr.IsAlreadyExists(err) {
+		// the sync rules are installed regardless, the builders reading them at startup are rolled should they differ
+		rolled, err := rollSyncRules(k, annotations[syncRulesChecksumAnnotation])
+		if err != nil {
+			return err
+		}
+		if rolled {
+			return errors.Errorf("buildkit already installed, pass the --force option to recreate (the builders are restarting with the sync rules)")
+		}
 		return errors.Errorf("buildkit already installed, pass the --force option to recreate")
 	}
 	return err
 }
 
+// rollSyncRules sets the checksum of the sync rules on the pod template of the installed builders, rolling them,
+// unless it is unchanged.
+func rollSyncRules(k *client.Interface, checksum string) (bool, error) {
+	rolled := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		daemon, err := k.Apps.DaemonSet().Get(k.Namespace, "builder", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if daemon.Spec.Template.Annotations[syncRulesChecksumAnnotation] == checksum {
+			return nil
+		}
+		if checksum == "" {
+			delete(daemon.Spec.Template.Annotations, syncRulesChecksumAnnotation)
+		} else {
+			if daemon.Spec.Template.Annotations == nil {
+				daemon.Spec.Template.Annotations = map[string]string{}
+			}
+			daemon.Spec.Template.Annotations[syncRulesChecksumAnnotation] = checksum
+		}
+		_, err = k.Apps.DaemonSet().Update(daemon)
+		rolled = err == nil
+		return err
+	})
+	return rolled, err
+}
+
 func (a *InstallBuilder) NodeRole(_ context.Context, k *client.Interface) error {
 	nodeList, err := k.Core.Node().List(metav1.ListOptions{
 		LabelSelector: a.Selector,
@@ -0,0 +1,222 @@
+package action
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/version"
+	"golang.org/x/crypto/ssh/terminal"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+)
+
+const (
+	defaultRegistryServer = "https://index.docker.io/v1/"
+)
+
+type LoginRegistry struct {
+	List          bool   `usage:"List the registries with stored credentials"`
+	Password      string `usage:"Password" short:"p"`
+	PasswordStdin bool   `usage:"Take the password from stdin"`
+	Username      string `usage:"Username" short:"u"`
+}
+
+func (s *LoginRegistry) Invoke(ctx context.Context, k8s *client.Interface, server string) error {
+	if s.List {
+		return listRegistryAuth(k8s)
+	}
+	if s.Password != "" && s.PasswordStdin {
+		return errors.New("--password and --password-stdin are mutually exclusive")
+	}
+	if s.PasswordStdin {
+		if s.Username == "" {
+			return errors.New("must provide --username with --password-stdin")
+		}
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		s.Password = strings.TrimRight(string(data), "\r\n")
+	}
+	if err := s.prompt(server); err != nil {
+		return err
+	}
+	if s.Username == "" || s.Password == "" {
+		return errors.New("username and password are required")
+	}
+	// as with `docker login`, the credentials are only stored once the registry accepted them
+	err := DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		if !agentCapable(ctx, version.CapabilityLogin) {
+			return errors.New("the agent cannot verify registry credentials, upgrade it with `k3c install`")
+		}
+		_, err := imagesClient.Login(ctx, &imagesv1.LoginRequest{
+			Server: registryHost(server),
+			Auth: &criv1.AuthConfig{
+				Username: s.Username,
+				Password: s.Password,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	key := registryServer(server)
+	err = updateRegistryAuth(k8s, func(config credentialprovider.DockerConfig) {
+		config[key] = credentialprovider.DockerConfigEntry{
+			Username: s.Username,
+			Password: s.Password,
+		}
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Login Succeeded")
+	return nil
+}
+
+// prompt for missing credentials when attached to a terminal
+func (s *LoginRegistry) prompt(server string) error {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil
+	}
+	if s.Username == "" {
+		fmt.Printf("Username for %s: ", registryHost(server))
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return err
+		}
+		s.Username = strings.TrimSpace(line)
+	}
+	if s.Password == "" {
+		fmt.Print("Password: ")
+		password, err := terminal.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return err
+		}
+		s.Password = string(password)
+	}
+	return nil
+}
+
+type LogoutRegistry struct {
+}
+
+func (s *LogoutRegistry) Invoke(ctx context.Context, k8s *client.Interface, server string) error {
+	host := registryHost(server)
+	found := false
+	err := updateRegistryAuth(k8s, func(config credentialprovider.DockerConfig) {
+		for key := range config {
+			if registryHost(key) == host {
+				delete(config, key)
+				found = true
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("not logged in to %s", host)
+	}
+	fmt.Printf("Removing login credentials for %s\n", host)
+	return nil
+}
+
+func listRegistryAuth(k8s *client.Interface) error {
+	config, err := k8s.RegistryAuth()
+	if err != nil {
+		return err
+	}
+	var servers []string
+	for key := range config {
+		servers = append(servers, key)
+	}
+	sort.Strings(servers)
+	display := newTableDisplay(20, 1, 3, ' ', 0)
+	display.AddRow([]string{"REGISTRY", "USERNAME"})
+	for _, key := range servers {
+		display.AddRow([]string{registryHost(key), config[key].Username})
+	}
+	return display.Flush()
+}
+
+// updateRegistryAuth applies the mutation to the registry credentials stored in-cluster, creating the secret as needed
+func updateRegistryAuth(k8s *client.Interface, mutate func(credentialprovider.DockerConfig)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := k8s.Core.Secret().Get(k8s.Namespace, client.RegistryAuthSecret, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      client.RegistryAuthSecret,
+					Namespace: k8s.Namespace,
+					Labels: labels.Set{
+						"app.kubernetes.io/managed-by": "k3c",
+					},
+				},
+				Type: corev1.SecretTypeDockerConfigJson,
+			}
+		} else if err != nil {
+			return err
+		}
+		config, err := client.ParseRegistryAuth(secret)
+		if err != nil {
+			return err
+		}
+		mutate(config)
+		data, err := json.Marshal(credentialprovider.DockerConfigJSON{Auths: config})
+		if err != nil {
+			return err
+		}
+		secret.Data = map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		}
+		if secret.ResourceVersion == "" {
+			_, err = k8s.Core.Secret().Create(secret)
+		} else {
+			_, err = k8s.Core.Secret().Update(secret)
+		}
+		return err
+	})
+}
+
+// registryServer returns the key for the server in the docker config, Docker Hub being keyed as the docker cli does
+func registryServer(server string) string {
+	host := registryHost(server)
+	if host == "docker.io" {
+		return defaultRegistryServer
+	}
+	return host
+}
+
+// registryHost strips the scheme and api path from the server, normalizing the Docker Hub aliases to docker.io
+func registryHost(server string) string {
+	host := server
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	host = strings.TrimSuffix(host, "/")
+	host = strings.TrimSuffix(host, "/v1")
+	host = strings.TrimSuffix(host, "/v2")
+	switch host {
+	case "", "docker.io", "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
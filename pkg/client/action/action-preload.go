@@ -0,0 +1,138 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/client"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+type PreloadImages struct {
+	AllPlatforms   bool     `usage:"Pull all platforms of multi-platform images"`
+	Concurrency    int      `usage:"Maximum number of images to pull concurrently" default:"4"`
+	DryRun         bool     `usage:"Only print the images that would be pulled"`
+	Filename       []string `usage:"Manifest files or directories to scan for images ('-' for stdin, e.g. helm template output)" short:"f"`
+	Platform       string   `usage:"Set platform to pull, e.g. linux/arm64 (default is that of the builder)"`
+	ServiceAccount string   `usage:"Use the imagePullSecrets of a ServiceAccount, as namespace/name or name in the k3c namespace (default is the k3c namespace default)"`
+}
+
+func (s *PreloadImages) Invoke(ctx context.Context, k8s *client.Interface) error {
+	if len(s.Filename) == 0 {
+		return errors.New("at least one manifest file or directory is required")
+	}
+	var images []string
+	for _, name := range s.Filename {
+		found, err := scanManifests(name)
+		if err != nil {
+			return err
+		}
+		images = append(images, found...)
+	}
+	images = uniqueImages(images)
+	sort.Strings(images)
+	if s.DryRun {
+		for _, image := range images {
+			fmt.Println(image)
+		}
+		return nil
+	}
+	// the pull requires an agent streaming progress, as does any other
+	pull := PullImage{
+		AllPlatforms:   s.AllPlatforms,
+		Concurrency:    s.Concurrency,
+		Platform:       s.Platform,
+		ServiceAccount: s.ServiceAccount,
+	}
+	return pull.Invoke(ctx, k8s, images)
+}
+
+// scanManifests returns the container images referenced by the workloads in the manifest file, recursing into
+// directories for .yaml, .yml and .json files.
+func scanManifests(path string) ([]string, error) {
+	if path == "-" {
+		return scanManifest(os.Stdin, "stdin")
+	}
+	var images []string
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			// explicitly named files are always scanned
+			if p != path {
+				return nil
+			}
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		found, err := scanManifest(f, p)
+		if err != nil {
+			return err
+		}
+		images = append(images, found...)
+		return nil
+	})
+	return images, err
+}
+
+func scanManifest(r io.Reader, name string) ([]string, error) {
+	var images []string
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var obj map[string]interface{}
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			return images, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", name)
+		}
+		images = append(images, podSpecImages(obj)...)
+	}
+}
+
+// podSpecImages walks the object collecting the images of containers, init containers and ephemeral containers. As
+// pod specs are embedded at different paths (Pod, Deployment, StatefulSet, Job, CronJob, List, ...) the whole object
+// is walked.
+func podSpecImages(obj interface{}) []string {
+	var images []string
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range o {
+			switch k {
+			case "containers", "initContainers", "ephemeralContainers":
+				if containers, ok := v.([]interface{}); ok {
+					for _, c := range containers {
+						if container, ok := c.(map[string]interface{}); ok {
+							if image, ok := container["image"].(string); ok && image != "" {
+								images = append(images, image)
+							}
+						}
+					}
+				}
+			default:
+				images = append(images, podSpecImages(v)...)
+			}
+		}
+	case []interface{}:
+		for _, v := range o {
+			images = append(images, podSpecImages(v)...)
+		}
+	}
+	return images
+}
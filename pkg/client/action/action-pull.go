@@ -1,72 +1,212 @@
 package action
 
 import (
+	"bufio"
 	"context"
 	"io"
 	"os"
+	"strings"
+	"sync"
 
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/rancher/k3c/pkg/progress"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
-	"k8s.io/kubernetes/pkg/credentialprovider"
 )
 
 type PullImage struct {
-	Platform string `usage:"Set platform if server is multi-platform capable"`
+	AllPlatforms   bool   `usage:"Pull all platforms of a multi-platform image"`
+	Concurrency    int    `usage:"Maximum number of images to pull concurrently" default:"4"`
+	File           string `usage:"Read images to pull from a file, one per line ('-' for stdin)" short:"f"`
+	Platform       string `usage:"Set platform to pull, e.g. linux/arm64 (default is that of the builder)"`
+	ServiceAccount string `usage:"Use the imagePullSecrets of a ServiceAccount, as namespace/name or name in the k3c namespace (default is the k3c namespace default)"`
 }
 
-func (s *PullImage) Invoke(ctx context.Context, k8s *client.Interface, image string) error {
+func (s *PullImage) Invoke(ctx context.Context, k8s *client.Interface, images []string) error {
+	if s.Platform != "" && s.AllPlatforms {
+		return errors.New("--platform and --all-platforms are mutually exclusive")
+	}
+	if s.File != "" {
+		list, err := readImageList(s.File)
+		if err != nil {
+			return err
+		}
+		images = append(images, list...)
+	}
+	images = uniqueImages(images)
+	if len(images) == 0 {
+		return errors.New("no images to pull")
+	}
 	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		if err := requireStreamingProgress(ctx); err != nil {
+			return err
+		}
 		ch := make(chan []imagesv1.ImageStatus)
-		eg, ctx := errgroup.WithContext(ctx)
+		display := errgroup.Group{}
 		// render output from the channel
-		eg.Go(func() error {
+		display.Go(func() error {
 			return progress.Display(ch, os.Stdout)
 		})
-		// render progress to the channel
-		eg.Go(func() error {
-			defer close(ch)
-			ppc, err := imagesClient.PullProgress(ctx, &imagesv1.ImageProgressRequest{Image: image})
+		failures := s.pullAll(ctx, imagesClient, images, newAggregateStatus(images, ch))
+		close(ch)
+		if err := display.Wait(); err != nil {
+			return err
+		}
+		if len(failures) == 1 && len(images) == 1 {
+			return failures[0]
+		}
+		for _, err := range failures {
+			logrus.Error(err)
+		}
+		if len(failures) > 0 {
+			return errors.Errorf("failed to pull %d of %d images", len(failures), len(images))
+		}
+		return nil
+	})
+}
+
+// pullAll pulls the images with a bounded number of workers, returning the errors of the failed pulls.
+func (s *PullImage) pullAll(ctx context.Context, imagesClient imagesv1.ImagesClient, images []string, status *aggregateStatus) []error {
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var (
+		failures []error
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, image := range images {
+		image := image
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			err := s.pull(ctx, imagesClient, image, func(st []imagesv1.ImageStatus) {
+				status.update(image, st)
+			})
 			if err != nil {
-				return err
-			}
-			for {
-				info, err := ppc.Recv()
-				if err == io.EOF {
-					return nil
-				}
-				if err != nil {
-					return err
-				}
-				ch <- info.Status
+				mu.Lock()
+				failures = append(failures, errors.Wrapf(err, "failed to pull %s", image))
+				mu.Unlock()
 			}
+		}()
+	}
+	wg.Wait()
+	return failures
+}
+
+func (s *PullImage) pull(ctx context.Context, imagesClient imagesv1.ImagesClient, image string, statusFn func([]imagesv1.ImageStatus)) error {
+	req := &imagesv1.ImagePullRequest{
+		Image: &criv1.ImageSpec{
+			Image: image,
+		},
+		Auth:           registryAuth(image),
+		Platform:       s.Platform,
+		AllPlatforms:   s.AllPlatforms,
+		ServiceAccount: s.ServiceAccount,
+	}
+	ppc, err := imagesClient.Pull(ctx, req)
+	if err != nil {
+		return err
+	}
+	// render progress to the callback until the final response
+	for {
+		res, err := ppc.Recv()
+		if err == io.EOF {
 			return nil
-		})
-		// initiate the pull
-		eg.Go(func() error {
-			req := &imagesv1.ImagePullRequest{
-				Image: &criv1.ImageSpec{
-					Image: image,
-				},
-			}
-			keyring := credentialprovider.NewDockerKeyring()
-			if auth, ok := keyring.Lookup(image); ok {
-				req.Auth = &criv1.AuthConfig{
-					Username:      auth[0].Username,
-					Password:      auth[0].Password,
-					Auth:          auth[0].Auth,
-					ServerAddress: auth[0].ServerAddress,
-					IdentityToken: auth[0].IdentityToken,
-					RegistryToken: auth[0].RegistryToken,
-				}
-			}
-			res, err := imagesClient.Pull(ctx, req)
-			logrus.Debugf("image-pull: %v", res)
+		}
+		if err != nil {
 			return err
-		})
-		return eg.Wait()
-	})
+		}
+		if res.Image != "" {
+			logrus.Debugf("image-pull: %v", res)
+			continue
+		}
+		statusFn(res.Status)
+	}
+}
+
+// aggregateStatus combines the progress of concurrent operations into a single stream for display
+type aggregateStatus struct {
+	order    []string
+	statuses map[string][]imagesv1.ImageStatus
+	ch       chan<- []imagesv1.ImageStatus
+	mu       sync.Mutex
+}
+
+func newAggregateStatus(order []string, ch chan<- []imagesv1.ImageStatus) *aggregateStatus {
+	return &aggregateStatus{
+		order:    order,
+		statuses: map[string][]imagesv1.ImageStatus{},
+		ch:       ch,
+	}
+}
+
+func (a *aggregateStatus) update(key string, status []imagesv1.ImageStatus) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.statuses[key] = status
+	var (
+		combined []imagesv1.ImageStatus
+		seen     = map[string]struct{}{}
+	)
+	for _, k := range a.order {
+		for _, st := range a.statuses[k] {
+			// layers shared between images are reported once
+			if _, ok := seen[st.Ref]; ok {
+				continue
+			}
+			seen[st.Ref] = struct{}{}
+			combined = append(combined, st)
+		}
+	}
+	a.ch <- combined
+}
+
+// readImageList reads image references, one per line, ignoring blank lines and #-comments
+func readImageList(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var list []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, strings.Fields(line)[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return list, nil
+}
+
+func uniqueImages(images []string) []string {
+	var (
+		unique []string
+		seen   = map[string]struct{}{}
+	)
+	for _, image := range images {
+		if _, ok := seen[image]; ok {
+			continue
+		}
+		seen[image] = struct{}{}
+		unique = append(unique, image)
+	}
+	return unique
 }
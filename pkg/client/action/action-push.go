@@ -4,68 +4,143 @@ import (
 	"context"
 	"io"
 	"os"
+	"sort"
 
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/rancher/k3c/pkg/progress"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
-	"k8s.io/kubernetes/pkg/credentialprovider"
 )
 
 type PushImage struct {
+	AllTags          bool     `usage:"Push all tags of the repository" short:"a"`
+	Compression      string   `usage:"Compress layers with gzip, zstd or estargz before pushing (default is as stored)"`
+	ForceCompression bool     `usage:"Recompress layers already compressed as requested"`
+	To               []string `usage:"Push the image to these references instead (may be repeated)"`
 }
 
 func (s *PushImage) Invoke(ctx context.Context, k8s *client.Interface, image string) error {
+	if s.AllTags && len(s.To) > 0 {
+		return errors.New("--all-tags and --to are mutually exclusive")
+	}
 	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		if err := requireStreamingProgress(ctx); err != nil {
+			return err
+		}
+		pushes := []string{image}
+		if s.AllTags {
+			tags, err := repositoryTags(ctx, imagesClient, image)
+			if err != nil {
+				return err
+			}
+			pushes = tags
+		}
 		ch := make(chan []imagesv1.ImageStatus)
-		eg, ctx := errgroup.WithContext(ctx)
+		display := errgroup.Group{}
 		// render output from the channel
-		eg.Go(func() error {
+		display.Go(func() error {
 			return progress.Display(ch, os.Stdout)
 		})
-		// render progress to the channel
-		eg.Go(func() error {
-			defer close(ch)
-			ppc, err := imagesClient.PushProgress(ctx, &imagesv1.ImageProgressRequest{Image: image})
+		// push the tags in turn so that blobs uploaded for one are found by the next
+		status := newAggregateStatus(pushes, ch)
+		var err error
+		for _, push := range pushes {
+			push := push
+			err = s.push(ctx, imagesClient, push, func(st []imagesv1.ImageStatus) {
+				status.update(push, st)
+			})
 			if err != nil {
-				return err
-			}
-			for {
-				info, err := ppc.Recv()
-				if err == io.EOF {
-					return nil
-				}
-				if err != nil {
-					return err
-				}
-				ch <- info.Status
+				err = errors.Wrapf(err, "failed to push %s", push)
+				break
 			}
+		}
+		close(ch)
+		if derr := display.Wait(); err == nil {
+			err = derr
+		}
+		return err
+	})
+}
+
+func (s *PushImage) push(ctx context.Context, imagesClient imagesv1.ImagesClient, image string, statusFn func([]imagesv1.ImageStatus)) error {
+	// the blobs of the image may be mounted from the repositories they were sourced from, requiring their credentials
+	sources := append(append([]string{image}, s.To...), imageSources(ctx, imagesClient, image)...)
+	req := &imagesv1.ImagePushRequest{
+		Image: &criv1.ImageSpec{
+			Image: image,
+		},
+		Auth:             registryAuth(sources...),
+		Targets:          s.To,
+		Compression:      s.Compression,
+		ForceCompression: s.ForceCompression,
+	}
+	ppc, err := imagesClient.Push(ctx, req)
+	if err != nil {
+		return err
+	}
+	// render progress to the callback until the final response
+	for {
+		res, err := ppc.Recv()
+		if err == io.EOF {
 			return nil
-		})
-		// initiate the push
-		eg.Go(func() error {
-			req := &imagesv1.ImagePushRequest{
-				Image: &criv1.ImageSpec{
-					Image: image,
-				},
-			}
-			keyring := credentialprovider.NewDockerKeyring()
-			if auth, ok := keyring.Lookup(image); ok {
-				req.Auth = &criv1.AuthConfig{
-					Username:      auth[0].Username,
-					Password:      auth[0].Password,
-					Auth:          auth[0].Auth,
-					ServerAddress: auth[0].ServerAddress,
-					IdentityToken: auth[0].IdentityToken,
-					RegistryToken: auth[0].RegistryToken,
-				}
-			}
-			res, err := imagesClient.Push(ctx, req)
-			logrus.Debugf("image-push: %v", res)
+		}
+		if err != nil {
 			return err
-		})
-		return eg.Wait()
+		}
+		if res.Image != "" {
+			logrus.Debugf("image-push: %v", res)
+			continue
+		}
+		statusFn(res.Status)
+	}
+}
+
+// imageSources returns the repositories that the content of the image is sourced from, as known to the builder
+func imageSources(ctx context.Context, imagesClient imagesv1.ImagesClient, image string) []string {
+	res, err := imagesClient.Status(ctx, &imagesv1.ImageStatusRequest{
+		Image: &criv1.ImageSpec{
+			Image: image,
+		},
 	})
+	if err != nil {
+		logrus.Debugf("image-push: failed to get the sources of %s: %v", image, err)
+		return nil
+	}
+	return res.Sources
+}
+
+// repositoryTags returns the tagged references of the repository known to the builder
+func repositoryTags(ctx context.Context, imagesClient imagesv1.ImagesClient, repository string) ([]string, error) {
+	named, err := refdocker.ParseNormalizedNamed(repository)
+	if err != nil {
+		return nil, err
+	}
+	if !refdocker.IsNameOnly(named) {
+		return nil, errors.Errorf("tag or digest cannot be combined with --all-tags: %s", repository)
+	}
+	res, err := imagesClient.List(ctx, &imagesv1.ImageListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, image := range res.Images {
+		for _, tag := range image.RepoTags {
+			ref, err := refdocker.ParseNormalizedNamed(tag)
+			if err != nil {
+				continue
+			}
+			if ref.Name() == named.Name() {
+				tags = append(tags, ref.String())
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return nil, errors.Errorf("no tags found for %s", repository)
+	}
+	sort.Strings(tags)
+	return tags, nil
 }
@@ -2,28 +2,66 @@ package action
 
 import (
 	"context"
+	"fmt"
 
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/version"
 	"github.com/sirupsen/logrus"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
 type RemoveImage struct {
+	Force bool `usage:"Force removal of images used by containers or tagged in multiple repositories" short:"f"`
 }
 
-func (s *RemoveImage) Invoke(ctx context.Context, k8s *client.Interface, image string) error {
+func (s *RemoveImage) Invoke(ctx context.Context, k8s *client.Interface, images []string) error {
 	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
-		req := &imagesv1.ImageRemoveRequest{
-			Image: &criv1.ImageSpec{
-				Image: image,
-			},
+		// older agents would ignore the force and remove the image regardless
+		if s.Force && !agentCapable(ctx, version.CapabilityForceRemove) {
+			return errors.New("the agent does not support forced removal, upgrade it with `k3c install`")
 		}
-		res, err := imagesClient.Remove(ctx, req)
-		if err != nil {
-			return err
+		var failures []error
+		for _, image := range images {
+			req := &imagesv1.ImageRemoveRequest{
+				Image: &criv1.ImageSpec{
+					Image: image,
+				},
+				Force: s.Force,
+			}
+			res, err := imagesClient.Remove(ctx, req)
+			if err != nil {
+				failures = append(failures, errors.Wrapf(err, "failed to remove %s", image))
+				continue
+			}
+			logrus.Debugf("%#v", res)
+			for _, ref := range res.Untagged {
+				fmt.Printf("Untagged: %s\n", familiarString(ref))
+			}
+			for _, id := range res.Deleted {
+				fmt.Printf("Deleted: %s\n", id)
+			}
+		}
+		if len(failures) == 1 && len(images) == 1 {
+			return failures[0]
+		}
+		for _, err := range failures {
+			logrus.Error(err)
+		}
+		if len(failures) > 0 {
+			return errors.Errorf("failed to remove %d of %d images", len(failures), len(images))
 		}
-		logrus.Debugf("%#v", res)
 		return nil
 	})
 }
+
+// familiarString shortens the reference as the docker cli would, e.g. `docker.io/library/myapp:latest` is `myapp:latest`
+func familiarString(ref string) string {
+	named, err := refdocker.ParseNormalizedNamed(ref)
+	if err != nil {
+		return ref
+	}
+	return refdocker.FamiliarString(named)
+}
@@ -3,22 +3,31 @@ package action
 import (
 	"context"
 
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/version"
 	"github.com/sirupsen/logrus"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
 type TagImage struct {
+	FromNamespace string `usage:"Containerd namespace of the source image (default is the image namespace of the agent, k8s.io)"`
+	ToNamespace   string `usage:"Containerd namespace of the tags, copying the content of the image there (default is the image namespace of the agent, k8s.io)"`
 }
 
 func (s *TagImage) Invoke(ctx context.Context, k8s *client.Interface, image string, tags []string) error {
 	return DoImages(ctx, k8s, func(ctx context.Context, imagesClient imagesv1.ImagesClient) error {
+		if (s.FromNamespace != "" || s.ToNamespace != "") && !agentCapable(ctx, version.CapabilityNamespaces) {
+			return errors.New("the agent does not support namespaces, upgrade it with `k3c install`")
+		}
 		req := &imagesv1.ImageTagRequest{
 			Image: &criv1.ImageSpec{
 				Image: image,
 			},
-			Tags: tags,
+			Tags:          tags,
+			FromNamespace: s.FromNamespace,
+			ToNamespace:   s.ToNamespace,
 		}
 		res, err := imagesClient.Tag(ctx, req)
 		if err != nil {
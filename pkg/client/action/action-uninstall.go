@@ -57,6 +57,18 @@ func (_ *UninstallBuilder) Namespace(ctx context.Context, k *client.Interface) e
 	}
 }
 
+// ClusterRole removes the cluster-scoped role and binding of the builder, which are not removed with the namespace.
+func (_ *UninstallBuilder) ClusterRole(_ context.Context, k *client.Interface) error {
+	name := builderClusterRole(k)
+	if err := k.Rbac.ClusterRoleBinding().Delete(name, &metav1.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	if err := k.Rbac.ClusterRole().Delete(name, &metav1.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (a *UninstallBuilder) NodeRole(_ context.Context, k *client.Interface) error {
 	nodeList, err := k.Core.Node().List(metav1.ListOptions{
 		LabelSelector: "node-role.kubernetes.io/builder",
@@ -2,15 +2,18 @@ package action
 
 import (
 	"context"
-	"errors"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strconv"
 
 	buildkit "github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/rancher/k3c/pkg/client"
+	"github.com/rancher/k3c/pkg/version"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,12 +25,45 @@ func DoImages(ctx context.Context, k8s *client.Interface, fn DoImagesFunc) error
 	if err != nil {
 		return err
 	}
-	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	tlsConfig, err := k8s.AgentTLS()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the agent client certificate")
+	}
+	opts := append(unimplementedOptions(), grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	// agents authorizing callers expect the token of the kubeconfig
+	token, err := k8s.BearerToken()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the bearer token of the kubeconfig")
+	}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(token)))
+	}
+	conn, err := grpc.DialContext(ctx, addr, opts...)
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
-	return fn(ctx, imagesv1.NewImagesClient(conn))
+	imagesClient := imagesv1.NewImagesClient(conn)
+	agent, err := handshake(ctx, imagesClient)
+	if err != nil {
+		return err
+	}
+	ctx = withAgentVersion(ctx, agent)
+	if token == "" && agentCapable(ctx, version.CapabilityAuthorization) {
+		return errors.New("the agent requires the bearer token of a kubeconfig, which has none")
+	}
+	return fn(ctx, imagesClient)
+}
+
+// bearerToken is the per-RPC credentials of a kubernetes bearer token
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
 }
 
 func DoControl(ctx context.Context, k8s *client.Interface, fn DoControlFunc) error {
@@ -35,7 +71,15 @@ func DoControl(ctx context.Context, k8s *client.Interface, fn DoControlFunc) err
 	if err != nil {
 		return err
 	}
-	bkc, err := buildkit.New(ctx, fmt.Sprintf("tcp://%s", addr))
+	tlsConfig, err := k8s.AgentTLS()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the agent client certificate")
+	}
+	// buildkitd terminates TLS on its listener, serving plaintext gRPC over it, hence the dialer
+	dialer := &tls.Dialer{Config: tlsConfig}
+	bkc, err := buildkit.New(ctx, fmt.Sprintf("tcp://%s", addr), buildkit.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp", addr)
+	}))
 	if err != nil {
 		return err
 	}
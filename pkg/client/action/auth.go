@@ -0,0 +1,167 @@
+package action
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+)
+
+// dockerConfigFile is the subset of the docker cli config.json relevant to registry authentication
+type dockerConfigFile struct {
+	Auths       map[string]dockerAuthEntry `json:"auths"`
+	CredHelpers map[string]string          `json:"credHelpers"`
+	CredsStore  string                     `json:"credsStore"`
+}
+
+type dockerAuthEntry struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+	RegistryToken string `json:"registrytoken"`
+}
+
+// registryAuth returns the credentials for the registries of the images (or repositories), scoped to their registry.
+// Credentials are looked up, in order of precedence, from the docker credential helpers, the docker cli config file and
+// finally the docker keyring (as the kubelet would).
+func registryAuth(images ...string) []*criv1.AuthConfig {
+	config, err := loadDockerConfig()
+	if err != nil {
+		logrus.Warnf("failed to load docker config: %v", err)
+		config = &dockerConfigFile{}
+	}
+	var (
+		auths   []*criv1.AuthConfig
+		keyring = credentialprovider.NewDockerKeyring()
+		seen    = map[string]struct{}{}
+	)
+	for _, image := range images {
+		named, err := refdocker.ParseDockerRef(image)
+		if err != nil {
+			continue
+		}
+		host := registryHost(refdocker.Domain(named))
+		if _, ok := seen[host]; ok {
+			continue
+		}
+		seen[host] = struct{}{}
+		address := "https://" + host
+
+		if authConfig, err := config.helperAuth(host); err != nil {
+			logrus.Warnf("failed to get credentials for %s: %v", host, err)
+		} else if authConfig != nil {
+			authConfig.ServerAddress = address
+			auths = append(auths, authConfig)
+			continue
+		}
+		if authConfig := config.fileAuth(host); authConfig != nil {
+			authConfig.ServerAddress = address
+			auths = append(auths, authConfig)
+			continue
+		}
+		if found, ok := keyring.Lookup(image); ok {
+			for _, a := range found {
+				auths = append(auths, &criv1.AuthConfig{
+					Username:      a.Username,
+					Password:      a.Password,
+					Auth:          a.Auth,
+					ServerAddress: address,
+					IdentityToken: a.IdentityToken,
+					RegistryToken: a.RegistryToken,
+				})
+			}
+		}
+	}
+	return auths
+}
+
+// loadDockerConfig loads the docker cli config file, a missing file yields an empty configuration
+func loadDockerConfig() (*dockerConfigFile, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &dockerConfigFile{}, nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	config := &dockerConfigFile{}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", filepath.Join(dir, "config.json"))
+	}
+	return config, nil
+}
+
+// helperAuth runs the credential helper configured for the host (falling back to the credentials store), returning
+// nil when none is configured or it has no credentials for the host.
+func (c *dockerConfigFile) helperAuth(host string) (*criv1.AuthConfig, error) {
+	helper, ok := c.CredHelpers[host]
+	if !ok {
+		helper = c.CredsStore
+	}
+	if helper == "" {
+		return nil, nil
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(registryServer(host))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(message, "credentials not found") {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "docker-credential-%s: %s", helper, message)
+	}
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, errors.Wrapf(err, "docker-credential-%s", helper)
+	}
+	// helpers signal identity tokens with a special username
+	if creds.Username == "<token>" {
+		return &criv1.AuthConfig{IdentityToken: creds.Secret}, nil
+	}
+	return &criv1.AuthConfig{Username: creds.Username, Password: creds.Secret}, nil
+}
+
+// fileAuth returns the credentials stored in the config file for the host, including identity and registry tokens
+// which the docker keyring ignores.
+func (c *dockerConfigFile) fileAuth(host string) *criv1.AuthConfig {
+	for key, entry := range c.Auths {
+		if registryHost(key) != host {
+			continue
+		}
+		if entry == (dockerAuthEntry{}) {
+			continue
+		}
+		return &criv1.AuthConfig{
+			Username:      entry.Username,
+			Password:      entry.Password,
+			Auth:          entry.Auth,
+			IdentityToken: entry.IdentityToken,
+			RegistryToken: entry.RegistryToken,
+		}
+	}
+	return nil
+}
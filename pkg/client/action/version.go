@@ -0,0 +1,108 @@
+package action
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/version"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type agentVersionKey struct{}
+
+// handshake exchanges versions with the agent, failing when their api versions are incompatible. Agents that predate
+// the handshake are incompatible.
+func handshake(ctx context.Context, imagesClient imagesv1.ImagesClient) (*imagesv1.VersionResponse, error) {
+	res, err := imagesClient.Version(ctx, &imagesv1.VersionRequest{
+		Version:      version.Version,
+		GitCommit:    version.GitCommit,
+		ApiVersion:   version.APIVersion,
+		Capabilities: version.Capabilities,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, errors.Errorf("the agent predates version negotiation and is too old for k3c %s (api versions %d to %d), upgrade it with `k3c install`",
+			version.Version, version.MinAPIVersion, version.APIVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if version.APIVersion < res.MinApiVersion {
+		return nil, errors.Errorf("k3c %s (api version %d) is too old for the agent %s (api versions %d to %d), please upgrade k3c",
+			version.Version, version.APIVersion, res.Version, res.MinApiVersion, res.ApiVersion)
+	}
+	if res.ApiVersion < version.MinAPIVersion {
+		return nil, errors.Errorf("the agent %s (api version %d) is too old for k3c %s (api versions %d to %d), upgrade it with `k3c install`",
+			res.Version, res.ApiVersion, version.Version, version.MinAPIVersion, version.APIVersion)
+	}
+	if res.Version != version.Version {
+		logrus.Warnf("k3c %s differs from the agent %s, consider upgrading with `k3c install`", version.Version, res.Version)
+	}
+	return res, nil
+}
+
+// requireStreamingProgress fails for agents whose pulls and pushes do not stream progress, as their unary Pull and Push
+// are incompatible with the streaming ones.
+func requireStreamingProgress(ctx context.Context) error {
+	if !agentCapable(ctx, version.CapabilityStreamingProgress) {
+		return errors.New("the agent does not stream the progress of pulls and pushes, upgrade it with `k3c install`")
+	}
+	return nil
+}
+
+// withAgentVersion returns the context carrying the version of the agent exchanged in the handshake
+func withAgentVersion(ctx context.Context, agent *imagesv1.VersionResponse) context.Context {
+	return context.WithValue(ctx, agentVersionKey{}, agent)
+}
+
+// agentCapable returns whether the agent of the context reported the capability in the handshake
+func agentCapable(ctx context.Context, capability string) bool {
+	agent, ok := ctx.Value(agentVersionKey{}).(*imagesv1.VersionResponse)
+	if !ok {
+		return false
+	}
+	for _, c := range agent.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// unimplementedOptions returns the interceptors explaining the calls that the agent does not implement, i.e. that
+// are newer than the agent.
+func unimplementedOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return unimplementedError(method, invoker(ctx, method, req, reply, cc, opts...))
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				return nil, unimplementedError(method, err)
+			}
+			return &unimplementedStream{ClientStream: stream, method: method}, nil
+		}),
+	}
+}
+
+// unimplementedStream explains the error of streams that the agent does not implement, received with the first message
+type unimplementedStream struct {
+	grpc.ClientStream
+	method string
+}
+
+func (s *unimplementedStream) RecvMsg(m interface{}) error {
+	return unimplementedError(s.method, s.ClientStream.RecvMsg(m))
+}
+
+func unimplementedError(method string, err error) error {
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	return status.Errorf(codes.Unimplemented, "the agent does not implement %s, upgrade it with `k3c install`", path.Base(method))
+}
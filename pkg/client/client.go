@@ -7,7 +7,13 @@ import (
 	appsctlv1 "github.com/rancher/wrangler/pkg/generated/controllers/apps/v1"
 	corectl "github.com/rancher/wrangler/pkg/generated/controllers/core"
 	corectlv1 "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	rbacctl "github.com/rancher/wrangler/pkg/generated/controllers/rbac"
+	rbacctlv1 "github.com/rancher/wrangler/pkg/generated/controllers/rbac/v1"
 	"github.com/rancher/wrangler/pkg/kubeconfig"
+	authnv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authzv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 )
 
 const (
@@ -22,20 +28,31 @@ type Config struct {
 	Namespace  string `usage:"namespace" default:"k3c" short:"n" env:"NAMESPACE"`
 	Kubeconfig string `usage:"kubeconfig for authentication" short:"k" env:"KUBECONFIG"`
 	Context    string `usage:"kubeconfig context for authentication" short:"x" env:"KUBECONTEXT"`
+	TLSDir     string `name:"tls-dir" usage:"Directory of the client certificate (tls.crt, tls.key) and CA (ca.crt) for the agent (default is the builder-client-tls secret)" env:"K3C_TLS_DIR"`
 }
 
 func (c *Config) Interface() (*Interface, error) {
 	if c == nil {
 		return nil, errors.Errorf("client is not configured, please set client config")
 	}
-	return NewInterface(c.Kubeconfig, c.Context, c.Namespace)
+	i, err := NewInterface(c.Kubeconfig, c.Context, c.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	i.TLSDir = c.TLSDir
+	return i, nil
 }
 
 type Interface struct {
-	Core      corectlv1.Interface
-	Apps      appsctlv1.Interface
-	Apply     apply.Apply
-	Namespace string
+	Core           corectlv1.Interface
+	Apps           appsctlv1.Interface
+	Rbac           rbacctlv1.Interface
+	Authentication authnv1.AuthenticationV1Interface
+	Authorization  authzv1.AuthorizationV1Interface
+	Apply          apply.Apply
+	Namespace      string
+	TLSDir         string
+	restConfig     *rest.Config
 }
 
 func NewInterface(kubecfg, kubectx, kubens string) (*Interface, error) {
@@ -55,7 +72,8 @@ func NewInterface(kubecfg, kubectx, kubens string) (*Interface, error) {
 	}
 
 	c := &Interface{
-		Namespace: ns,
+		Namespace:  ns,
+		restConfig: rc,
 	}
 
 	core, err := corectl.NewFactoryFromConfig(rc)
@@ -70,6 +88,22 @@ func NewInterface(kubecfg, kubectx, kubens string) (*Interface, error) {
 	}
 	c.Apps = apps.Apps().V1()
 
+	rbac, err := rbacctl.NewFactoryFromConfig(rc)
+	if err != nil {
+		return nil, err
+	}
+	c.Rbac = rbac.Rbac().V1()
+
+	c.Authentication, err = authnv1.NewForConfig(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Authorization, err = authzv1.NewForConfig(rc)
+	if err != nil {
+		return nil, err
+	}
+
 	c.Apply, err = apply.NewForConfig(rc)
 	if err != nil {
 		return nil, err
@@ -87,3 +121,11 @@ func NewInterface(kubecfg, kubectx, kubens string) (*Interface, error) {
 
 	return c, nil
 }
+
+// CoreForToken returns a core client authenticated by the bearer token instead of the credentials of the interface, so
+// that the API server authorizes its requests as those of the owner of the token
+func (i *Interface) CoreForToken(token string) (corev1.CoreV1Interface, error) {
+	rc := rest.AnonymousClientConfig(i.restConfig)
+	rc.BearerToken = token
+	return corev1.NewForConfig(rc)
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+)
+
+const (
+	// RegistryAuthSecret is the name of the kubernetes.io/dockerconfigjson secret, in the k3c namespace, holding the
+	// registry credentials stored via `k3c login`.
+	RegistryAuthSecret = "registry-auth"
+)
+
+// RegistryAuth returns the registry credentials stored in-cluster, keyed by registry. A missing secret yields empty
+// credentials.
+func (i *Interface) RegistryAuth() (credentialprovider.DockerConfig, error) {
+	secret, err := i.Core.Secret().Get(i.Namespace, RegistryAuthSecret, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		return credentialprovider.DockerConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegistryAuth(secret)
+}
+
+// ParseRegistryAuth returns the registry credentials of a kubernetes.io/dockerconfigjson secret.
+func ParseRegistryAuth(secret *corev1.Secret) (credentialprovider.DockerConfig, error) {
+	config := credentialprovider.DockerConfigJSON{}
+	if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok && len(data) > 0 {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse secret %s/%s", secret.Namespace, secret.Name)
+		}
+	}
+	if config.Auths == nil {
+		config.Auths = credentialprovider.DockerConfig{}
+	}
+	return config.Auths, nil
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/certs"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AgentCASecret is the name of the secret, in the k3c namespace, holding the certificate authority (and its key)
+	// issuing the agent server and client certificates.
+	AgentCASecret = "builder-ca"
+	// AgentServerSecret is the name of the kubernetes.io/tls secret, in the k3c namespace, holding the agent server
+	// certificate mounted by the builder.
+	AgentServerSecret = "builder-tls"
+	// AgentClientSecret is the name of the kubernetes.io/tls secret, in the k3c namespace, holding the client
+	// certificate presented to the agent.
+	AgentClientSecret = "builder-client-tls"
+)
+
+// AgentServerName is the name the agent server certificate is issued for, and verified against, regardless of the
+// address of the builder.
+func (i *Interface) AgentServerName() string {
+	return "builder." + i.Namespace + ".svc"
+}
+
+// AgentTLS returns the client TLS configuration for the agent, loaded from the TLS directory if set or else from the
+// client certificate secret. A missing secret fails rather than downgrading to an insecure connection.
+func (i *Interface) AgentTLS() (*tls.Config, error) {
+	var caPEM, certPEM, keyPEM []byte
+	if i.TLSDir != "" {
+		var err error
+		if caPEM, err = ioutil.ReadFile(filepath.Join(i.TLSDir, certs.CAFile)); err != nil {
+			return nil, err
+		}
+		if certPEM, err = ioutil.ReadFile(filepath.Join(i.TLSDir, certs.CertFile)); err != nil {
+			return nil, err
+		}
+		if keyPEM, err = ioutil.ReadFile(filepath.Join(i.TLSDir, certs.KeyFile)); err != nil {
+			return nil, err
+		}
+	} else {
+		secret, err := i.Core.Secret().Get(i.Namespace, AgentClientSecret, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			return nil, errors.Errorf("the agent client certificate (secret %s/%s) is missing, run `k3c install`", i.Namespace, AgentClientSecret)
+		}
+		if err != nil {
+			return nil, err
+		}
+		caPEM, certPEM, keyPEM = secret.Data[certs.CAFile], secret.Data[certs.CertFile], secret.Data[certs.KeyFile]
+	}
+	return certs.ClientConfig(caPEM, certPEM, keyPEM, i.AgentServerName())
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// BearerToken returns the bearer token that the kubeconfig authenticates with, be it static, read from a file or
+// obtained from an auth provider or exec plugin. Kubeconfigs authenticating otherwise, e.g. with client certificates,
+// yield no token.
+func (i *Interface) BearerToken() (string, error) {
+	if i.restConfig == nil {
+		return "", nil
+	}
+	// let client-go decorate a request as it would for the api server
+	capture := &authorizationCapture{}
+	rt, err := rest.HTTPWrappersForConfig(rest.CopyConfig(i.restConfig), capture)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, i.restConfig.Host, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err = rt.RoundTrip(req); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(capture.authorization, "Bearer ") {
+		return "", nil
+	}
+	return strings.TrimPrefix(capture.authorization, "Bearer "), nil
+}
+
+// authorizationCapture is a round tripper recording the authorization header rather than sending the request
+type authorizationCapture struct {
+	authorization string
+}
+
+func (c *authorizationCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.authorization = req.Header.Get("Authorization")
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
@@ -0,0 +1,517 @@
+package converter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	Uncompressed = ""
+	Gzip         = "gzip"
+	Zstd         = "zstd"
+	Estargz      = "estargz"
+
+	// MediaTypeImageLayerZstd is the media type of zstd compressed OCI layers
+	MediaTypeImageLayerZstd = "application/vnd.oci.image.layer.v1.tar+zstd"
+
+	// uncompressedLabel labels compressed layers with their diff id
+	uncompressedLabel = "containerd.io/uncompressed"
+)
+
+// Options of the conversion
+type Options struct {
+	// Compression of the layers: gzip, zstd or estargz. Empty leaves the layers as they are.
+	Compression string
+	// Force recompression of the layers already compressed as requested.
+	Force bool
+	// OCI converts docker media types to their OCI counterparts, implied by zstd compression.
+	OCI bool
+}
+
+// Validate the options
+func (o *Options) Validate() error {
+	switch o.Compression {
+	case Uncompressed, Gzip, Estargz:
+	case Zstd:
+		// docker manifests have no media type for zstd layers
+		o.OCI = true
+	default:
+		return errors.Errorf("unsupported compression %q, expected one of gzip, zstd or estargz", o.Compression)
+	}
+	return nil
+}
+
+// IsNoop returns whether the conversion leaves images as they are
+func (o *Options) IsNoop() bool {
+	return o.Compression == Uncompressed && !o.OCI
+}
+
+// Convert converts the image rooted at the descriptor, writing the converted content to the store, and returns the
+// descriptor of the converted image, which is the passed descriptor when nothing changed. The caller is expected to
+// hold a lease so that the converted content is not garbage collected before being referenced.
+func Convert(ctx context.Context, store content.Store, desc ocispec.Descriptor, opts Options) (ocispec.Descriptor, error) {
+	if err := opts.Validate(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if opts.IsNoop() {
+		return desc, nil
+	}
+	c := &converter{
+		store:  store,
+		opts:   opts,
+		layers: map[digest.Digest]*layer{},
+	}
+	return c.convert(ctx, desc)
+}
+
+type converter struct {
+	store  content.Store
+	opts   Options
+	layers map[digest.Digest]*layer
+	mu     sync.Mutex
+}
+
+// layer is the result of the conversion of a layer blob, shared by the platforms of an image
+type layer struct {
+	once        sync.Once
+	err         error
+	compression string
+	digest      digest.Digest
+	size        int64
+	// uncompressed is the digest of the uncompressed layer, which is its diff id
+	uncompressed digest.Digest
+	// diffID is the diff id of a layer whose uncompressed content changed, i.e. of eStargz layers
+	diffID    digest.Digest
+	tocDigest digest.Digest
+}
+
+// index is an OCI index or a docker manifest list, the latter requiring the media type
+type index struct {
+	MediaType string `json:"mediaType,omitempty"`
+	ocispec.Index
+}
+
+// manifest is an OCI or docker manifest, the latter requiring the media type
+type manifest struct {
+	MediaType string `json:"mediaType,omitempty"`
+	ocispec.Manifest
+}
+
+func (c *converter) convert(ctx context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		return c.convertIndex(ctx, desc)
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+		return c.convertManifest(ctx, desc)
+	default:
+		return ocispec.Descriptor{}, errors.Errorf("cannot convert %s of type %s", desc.Digest, desc.MediaType)
+	}
+}
+
+func (c *converter) convertIndex(ctx context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	var idx index
+	if err := c.read(ctx, desc, &idx); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	mediaType := desc.MediaType
+	if c.opts.OCI {
+		mediaType = ocispec.MediaTypeImageIndex
+	}
+	changed := mediaType != desc.MediaType
+	labels := map[string]string{}
+	var manifests []ocispec.Descriptor
+	for _, m := range idx.Manifests {
+		// as pulls only fetch the platform of the host by default, the platforms that were not pulled are left out of
+		// the converted index, as with the converter of containerd
+		if _, err := c.store.Info(ctx, m.Digest); errdefs.IsNotFound(err) {
+			changed = true
+			continue
+		}
+		converted, err := c.convert(ctx, m)
+		if err != nil {
+			return ocispec.Descriptor{}, errors.Wrapf(err, "failed to convert platform %s", platformString(m.Platform))
+		}
+		changed = changed || converted.Digest != m.Digest || converted.MediaType != m.MediaType
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.m.%d", len(manifests))] = converted.Digest.String()
+		manifests = append(manifests, converted)
+	}
+	if len(manifests) == 0 {
+		return ocispec.Descriptor{}, errors.Wrapf(errdefs.ErrNotFound, "content of no platform of %s", desc.Digest)
+	}
+	idx.Manifests = manifests
+	if !changed {
+		return desc, nil
+	}
+	if idx.MediaType != "" {
+		idx.MediaType = mediaType
+	}
+	return c.write(ctx, desc, mediaType, idx, labels)
+}
+
+func (c *converter) convertManifest(ctx context.Context, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	var mf manifest
+	if err := c.read(ctx, desc, &mf); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	mediaType := desc.MediaType
+	if c.opts.OCI {
+		mediaType = ocispec.MediaTypeImageManifest
+	}
+	docker := mediaType == images.MediaTypeDockerSchema2Manifest
+
+	converted := make([]*layer, len(mf.Layers))
+	eg, egctx := errgroup.WithContext(ctx)
+	for i := range mf.Layers {
+		i := i
+		eg.Go(func() (err error) {
+			converted[i], err = c.convertLayer(egctx, mf.Layers[i])
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	changed := mediaType != desc.MediaType
+	labels := map[string]string{}
+	diffIDs := map[int]digest.Digest{}
+	for i, l := range converted {
+		original := mf.Layers[i]
+		if l == nil {
+			// not converted, e.g. non-distributable layers
+			mf.Layers[i].MediaType = layerMediaType(original.MediaType, docker)
+		} else {
+			mf.Layers[i] = l.descriptor(original, docker)
+			if l.diffID != "" {
+				diffIDs[i] = l.diffID
+			}
+		}
+		changed = changed || mf.Layers[i].Digest != original.Digest || mf.Layers[i].MediaType != original.MediaType
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.l.%d", i)] = mf.Layers[i].Digest.String()
+	}
+
+	config, err := c.convertConfig(ctx, mf.Config, diffIDs, docker)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	changed = changed || config.Digest != mf.Config.Digest || config.MediaType != mf.Config.MediaType
+	if !changed {
+		return desc, nil
+	}
+	mf.Config = config
+	labels["containerd.io/gc.ref.content.config"] = config.Digest.String()
+	if mf.MediaType != "" {
+		mf.MediaType = mediaType
+	}
+	return c.write(ctx, desc, mediaType, mf, labels)
+}
+
+// convertConfig updates the media type of the config and, when layers were rewritten, its diff ids
+func (c *converter) convertConfig(ctx context.Context, desc ocispec.Descriptor, diffIDs map[int]digest.Digest, docker bool) (ocispec.Descriptor, error) {
+	mediaType := desc.MediaType
+	if !docker && mediaType == images.MediaTypeDockerSchema2Config {
+		mediaType = ocispec.MediaTypeImageConfig
+	}
+	if len(diffIDs) == 0 {
+		desc.MediaType = mediaType
+		return desc, nil
+	}
+	var config map[string]json.RawMessage
+	if err := c.read(ctx, desc, &config); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	var rootfs ocispec.RootFS
+	if err := json.Unmarshal(config["rootfs"], &rootfs); err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to parse rootfs of config %s", desc.Digest)
+	}
+	for i, diffID := range diffIDs {
+		if i >= len(rootfs.DiffIDs) {
+			return ocispec.Descriptor{}, errors.Errorf("config %s has no diff id for layer %d", desc.Digest, i)
+		}
+		rootfs.DiffIDs[i] = diffID
+	}
+	data, err := json.Marshal(rootfs)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	config["rootfs"] = data
+	return c.write(ctx, desc, mediaType, config, nil)
+}
+
+// convertLayer (re)compresses the layer as requested, returning nil for layers that are left as they are
+func (c *converter) convertLayer(ctx context.Context, desc ocispec.Descriptor) (*layer, error) {
+	source, ok := layerCompression(desc)
+	if !ok {
+		return nil, nil
+	}
+	target := c.opts.Compression
+	if target == Uncompressed || (!c.opts.Force && (target == source || target == Gzip && source == Estargz)) {
+		return nil, nil
+	}
+
+	c.mu.Lock()
+	l, ok := c.layers[desc.Digest]
+	if !ok {
+		l = &layer{compression: target}
+		c.layers[desc.Digest] = l
+	}
+	c.mu.Unlock()
+
+	l.once.Do(func() {
+		l.err = c.compress(ctx, desc, source, l)
+		if l.err != nil {
+			l.err = errors.Wrapf(l.err, "failed to convert layer %s", desc.Digest)
+		}
+	})
+	return l, l.err
+}
+
+func (c *converter) compress(ctx context.Context, desc ocispec.Descriptor, source string, l *layer) error {
+	ra, err := c.store.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	rc, err := decompress(content.NewReader(ra), source)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	diffID := digest.Canonical.Digester()
+	uncompressed := io.TeeReader(rc, diffID.Hash())
+
+	blob, err := ioutil.TempFile("", "k3c-convert-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(blob.Name())
+	defer blob.Close()
+	digester := digest.Canonical.Digester()
+	w := io.MultiWriter(blob, digester.Hash())
+
+	switch l.compression {
+	case Gzip:
+		gz := gzip.NewWriter(w)
+		if _, err = io.Copy(gz, uncompressed); err != nil {
+			return err
+		}
+		err = gz.Close()
+	case Zstd:
+		var zw *zstd.Encoder
+		if zw, err = zstd.NewWriter(w); err != nil {
+			return err
+		}
+		if _, err = io.Copy(zw, uncompressed); err != nil {
+			zw.Close()
+			return err
+		}
+		err = zw.Close()
+	case Estargz:
+		err = l.buildEstargz(uncompressed, w)
+	}
+	if err != nil {
+		return err
+	}
+
+	if l.size, err = blob.Seek(0, io.SeekCurrent); err != nil {
+		return err
+	}
+	if _, err = blob.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	l.digest = digester.Digest()
+	l.uncompressed = diffID.Digest()
+	if l.diffID != "" {
+		l.uncompressed = l.diffID
+	}
+	ref := "convert-" + l.digest.String()
+	if err := content.WriteBlob(ctx, c.store, ref, blob, ocispec.Descriptor{Digest: l.digest, Size: l.size}); err != nil {
+		return err
+	}
+	// the diff id is labeled, as by pulls, so that unpacking the layer needs not compute it again. Content that
+	// already existed is labeled as well.
+	_, err = c.store.Update(ctx, content.Info{
+		Digest: l.digest,
+		Labels: map[string]string{uncompressedLabel: l.uncompressed.String()},
+	}, "labels."+uncompressedLabel)
+	return err
+}
+
+// buildEstargz writes the eStargz blob of the tar stream, which reorders the tar entries and thus changes the diff id
+func (l *layer) buildEstargz(tarStream io.Reader, w io.Writer) (err error) {
+	// estargz panics, rather than failing, when the gzip footer it writes is not of the size it expects, as is
+	// the case with some Go releases. That must fail the conversion, not take the agent down.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to build the eStargz blob: %v", r)
+		}
+	}()
+	tarFile, err := ioutil.TempFile("", "k3c-convert-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tarFile.Name())
+	defer tarFile.Close()
+	size, err := io.Copy(tarFile, tarStream)
+	if err != nil {
+		return err
+	}
+	blob, err := estargz.Build(io.NewSectionReader(tarFile, 0, size))
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, blob); err != nil {
+		blob.Close()
+		return err
+	}
+	if err = blob.Close(); err != nil {
+		return err
+	}
+	l.diffID = blob.DiffID()
+	l.tocDigest = blob.TOCDigest()
+	return nil
+}
+
+// descriptor returns the descriptor of the converted layer
+func (l *layer) descriptor(desc ocispec.Descriptor, docker bool) ocispec.Descriptor {
+	converted := ocispec.Descriptor{
+		MediaType: compressedMediaType(l.compression, docker),
+		Digest:    l.digest,
+		Size:      l.size,
+		URLs:      desc.URLs,
+	}
+	for k, v := range desc.Annotations {
+		if k == estargz.TOCJSONDigestAnnotation {
+			continue
+		}
+		if converted.Annotations == nil {
+			converted.Annotations = map[string]string{}
+		}
+		converted.Annotations[k] = v
+	}
+	if l.tocDigest != "" {
+		if converted.Annotations == nil {
+			converted.Annotations = map[string]string{}
+		}
+		converted.Annotations[estargz.TOCJSONDigestAnnotation] = l.tocDigest.String()
+	}
+	return converted
+}
+
+// read the json content of the descriptor
+func (c *converter) read(ctx context.Context, desc ocispec.Descriptor, v interface{}) error {
+	data, err := content.ReadBlob(ctx, c.store, desc)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", desc.Digest)
+	}
+	return nil
+}
+
+// write the json content, returning the descriptor updated accordingly
+func (c *converter) write(ctx context.Context, desc ocispec.Descriptor, mediaType string, v interface{}, labels map[string]string) (ocispec.Descriptor, error) {
+	data, err := json.MarshalIndent(v, "", "   ")
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc.MediaType = mediaType
+	desc.Digest = digest.FromBytes(data)
+	desc.Size = int64(len(data))
+	ref := "convert-" + desc.Digest.String()
+	if err := content.WriteBlob(ctx, c.store, ref, bytes.NewReader(data), desc, content.WithLabels(labels)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// layerCompression returns the compression of a distributable layer
+func layerCompression(desc ocispec.Descriptor) (string, bool) {
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2LayerGzip, ocispec.MediaTypeImageLayerGzip:
+		if _, ok := desc.Annotations[estargz.TOCJSONDigestAnnotation]; ok {
+			return Estargz, true
+		}
+		return Gzip, true
+	case images.MediaTypeDockerSchema2Layer, ocispec.MediaTypeImageLayer:
+		return Uncompressed, true
+	case MediaTypeImageLayerZstd:
+		return Zstd, true
+	}
+	return "", false
+}
+
+func decompress(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case Gzip, Estargz:
+		return gzip.NewReader(r)
+	case Zstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	}
+	return ioutil.NopCloser(r), nil
+}
+
+func compressedMediaType(compression string, docker bool) string {
+	switch {
+	case docker && compression == Uncompressed:
+		return images.MediaTypeDockerSchema2Layer
+	case docker:
+		return images.MediaTypeDockerSchema2LayerGzip
+	case compression == Uncompressed:
+		return ocispec.MediaTypeImageLayer
+	case compression == Zstd:
+		return MediaTypeImageLayerZstd
+	}
+	return ocispec.MediaTypeImageLayerGzip
+}
+
+// layerMediaType maps the media type of a layer that is not converted to OCI, if need be
+func layerMediaType(mediaType string, docker bool) string {
+	if docker {
+		return mediaType
+	}
+	switch mediaType {
+	case images.MediaTypeDockerSchema2Layer:
+		return ocispec.MediaTypeImageLayer
+	case images.MediaTypeDockerSchema2LayerGzip:
+		return ocispec.MediaTypeImageLayerGzip
+	case images.MediaTypeDockerSchema2LayerForeign:
+		return ocispec.MediaTypeImageLayerNonDistributable
+	case images.MediaTypeDockerSchema2LayerForeignGzip:
+		return ocispec.MediaTypeImageLayerNonDistributableGzip
+	}
+	return mediaType
+}
+
+func platformString(p *ocispec.Platform) string {
+	if p == nil {
+		return "unknown"
+	}
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
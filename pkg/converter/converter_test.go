@@ -0,0 +1,356 @@
+package converter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/stargz-snapshotter/estargz"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// labelStore keeps the labels of the content in memory, the local store being immutable without one
+type labelStore struct {
+	mu     sync.Mutex
+	labels map[digest.Digest]map[string]string
+}
+
+func (s *labelStore) Get(dgst digest.Digest) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.labels[dgst], nil
+}
+
+func (s *labelStore) Set(dgst digest.Digest, labels map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.labels[dgst] = labels
+	return nil
+}
+
+func (s *labelStore) Update(dgst digest.Digest, update map[string]string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	labels := map[string]string{}
+	for k, v := range s.labels[dgst] {
+		labels[k] = v
+	}
+	for k, v := range update {
+		if v == "" {
+			delete(labels, k)
+		} else {
+			labels[k] = v
+		}
+	}
+	s.labels[dgst] = labels
+	return labels, nil
+}
+
+// fixture is an image written to a content store
+type fixture struct {
+	t      *testing.T
+	ctx    context.Context
+	store  content.Store
+	tar    []byte
+	diffID digest.Digest
+}
+
+func newFixture(t *testing.T) *fixture {
+	store, err := local.NewLabeledStore(t.TempDir(), &labelStore{labels: map[digest.Digest]map[string]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	data := []byte("hello k3c\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0644, Size: int64(len(data))}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(data)
+	tw.Close()
+	return &fixture{
+		t:      t,
+		ctx:    context.Background(),
+		store:  store,
+		tar:    buf.Bytes(),
+		diffID: digest.FromBytes(buf.Bytes()),
+	}
+}
+
+// blob writes the content, returning its descriptor
+func (f *fixture) blob(mediaType string, data []byte) ocispec.Descriptor {
+	desc := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(data), Size: int64(len(data))}
+	if err := content.WriteBlob(f.ctx, f.store, desc.Digest.String(), bytes.NewReader(data), desc); err != nil {
+		f.t.Fatal(err)
+	}
+	return desc
+}
+
+// json writes the value as json content, returning its descriptor
+func (f *fixture) json(mediaType string, v interface{}) ocispec.Descriptor {
+	data, err := json.Marshal(v)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return f.blob(mediaType, data)
+}
+
+// manifest writes a docker manifest of the single layer, compressed with gzip unless uncompressed
+func (f *fixture) manifest(uncompressed bool, platform string) ocispec.Descriptor {
+	layer := f.blob(images.MediaTypeDockerSchema2Layer, f.tar)
+	if !uncompressed {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		gw.Write(f.tar)
+		gw.Close()
+		layer = f.blob(images.MediaTypeDockerSchema2LayerGzip, buf.Bytes())
+	}
+	config := f.json(images.MediaTypeDockerSchema2Config, ocispec.Image{
+		Architecture: platform,
+		OS:           "linux",
+		RootFS:       ocispec.RootFS{Type: "layers", DiffIDs: []digest.Digest{f.diffID}},
+	})
+	return f.json(images.MediaTypeDockerSchema2Manifest, manifest{
+		MediaType: images.MediaTypeDockerSchema2Manifest,
+		Manifest: ocispec.Manifest{
+			Config: config,
+			Layers: []ocispec.Descriptor{layer},
+		},
+	})
+}
+
+// index writes a docker manifest list of the platforms, the manifests of those missing not being written
+func (f *fixture) index(present map[string]bool) ocispec.Descriptor {
+	idx := index{MediaType: images.MediaTypeDockerSchema2ManifestList}
+	for _, arch := range []string{"amd64", "arm64"} {
+		desc := f.manifest(true, arch)
+		if !present[arch] {
+			// manifests of the same layer differ by their config only, deleting the manifest leaves it missing
+			if err := f.store.Delete(f.ctx, desc.Digest); err != nil {
+				f.t.Fatal(err)
+			}
+		}
+		desc.Platform = &ocispec.Platform{OS: "linux", Architecture: arch}
+		idx.Manifests = append(idx.Manifests, desc)
+	}
+	return f.json(images.MediaTypeDockerSchema2ManifestList, idx)
+}
+
+// estargzBuilds returns whether estargz builds blobs with this Go release, its gzip footer depending on that of
+// compress/gzip
+func estargzBuilds(t *testing.T) (ok bool) {
+	f := newFixture(t)
+	defer func() {
+		if r := recover(); r != nil {
+			t.Logf("estargz does not build blobs: %v", r)
+			ok = false
+		}
+	}()
+	blob, err := estargz.Build(io.NewSectionReader(bytes.NewReader(f.tar), 0, int64(len(f.tar))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob.Close()
+	return true
+}
+
+func TestConvertManifest(t *testing.T) {
+	canEstargz := estargzBuilds(t)
+	tests := []struct {
+		name          string
+		uncompressed  bool
+		opts          Options
+		unchanged     bool
+		manifestType  string
+		layerType     string
+		layerLabelled bool
+		// diffIDChanged is whether the uncompressed content, and thus the diff id in the config, is rewritten
+		diffIDChanged bool
+		toc           bool
+	}{
+		{
+			name:          "gzip",
+			uncompressed:  true,
+			opts:          Options{Compression: Gzip},
+			manifestType:  images.MediaTypeDockerSchema2Manifest,
+			layerType:     images.MediaTypeDockerSchema2LayerGzip,
+			layerLabelled: true,
+		},
+		{
+			name:          "zstd implies oci",
+			opts:          Options{Compression: Zstd},
+			manifestType:  ocispec.MediaTypeImageManifest,
+			layerType:     MediaTypeImageLayerZstd,
+			layerLabelled: true,
+		},
+		{
+			name:      "already gzip",
+			opts:      Options{Compression: Gzip},
+			unchanged: true,
+		},
+		{
+			name:          "forced gzip",
+			opts:          Options{Compression: Gzip, Force: true},
+			manifestType:  images.MediaTypeDockerSchema2Manifest,
+			layerType:     images.MediaTypeDockerSchema2LayerGzip,
+			layerLabelled: true,
+		},
+		{
+			name:          "estargz rewrites the diff id",
+			uncompressed:  true,
+			opts:          Options{Compression: Estargz},
+			manifestType:  images.MediaTypeDockerSchema2Manifest,
+			layerType:     images.MediaTypeDockerSchema2LayerGzip,
+			layerLabelled: true,
+			diffIDChanged: true,
+			toc:           true,
+		},
+		{
+			name:          "estargz of a gzip layer",
+			opts:          Options{Compression: Estargz},
+			manifestType:  images.MediaTypeDockerSchema2Manifest,
+			layerType:     images.MediaTypeDockerSchema2LayerGzip,
+			layerLabelled: true,
+			diffIDChanged: true,
+			toc:           true,
+		},
+		{
+			name:         "oci only",
+			opts:         Options{OCI: true},
+			manifestType: ocispec.MediaTypeImageManifest,
+			layerType:    ocispec.MediaTypeImageLayerGzip,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			desc := f.manifest(tt.uncompressed, "amd64")
+			converted, err := Convert(f.ctx, f.store, desc, tt.opts)
+			if tt.opts.Compression == Estargz && !canEstargz {
+				// the conversion must fail rather than panic
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				t.Skipf("estargz does not support the gzip of this Go release: %v", err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.unchanged {
+				if converted.Digest != desc.Digest {
+					t.Fatalf("converted %s to %s, expected it unchanged", desc.Digest, converted.Digest)
+				}
+				return
+			}
+			if converted.MediaType != tt.manifestType {
+				t.Errorf("manifest media type is %s, expected %s", converted.MediaType, tt.manifestType)
+			}
+			var mf manifest
+			data, err := content.ReadBlob(f.ctx, f.store, converted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, &mf); err != nil {
+				t.Fatal(err)
+			}
+			layer := mf.Layers[0]
+			if layer.MediaType != tt.layerType {
+				t.Errorf("layer media type is %s, expected %s", layer.MediaType, tt.layerType)
+			}
+			if _, ok := layer.Annotations[estargz.TOCJSONDigestAnnotation]; ok != tt.toc {
+				t.Errorf("layer has a TOC digest: %t, expected %t", ok, tt.toc)
+			}
+			var config ocispec.Image
+			data, err = content.ReadBlob(f.ctx, f.store, mf.Config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, &config); err != nil {
+				t.Fatal(err)
+			}
+			diffID := config.RootFS.DiffIDs[0]
+			if changed := diffID != f.diffID; changed != tt.diffIDChanged {
+				t.Errorf("diff id of the config is %s, changed from %s: %t, expected %t", diffID, f.diffID, changed, tt.diffIDChanged)
+			}
+			info, err := f.store.Info(f.ctx, layer.Digest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if label := info.Labels[uncompressedLabel]; tt.layerLabelled && label != diffID.String() {
+				t.Errorf("layer is labeled with diff id %q, expected that of the config %s", label, diffID)
+			}
+		})
+	}
+}
+
+func TestConvertIndex(t *testing.T) {
+	tests := []struct {
+		name      string
+		present   map[string]bool
+		platforms []string
+		wantErr   bool
+	}{
+		{
+			name:      "all platforms",
+			present:   map[string]bool{"amd64": true, "arm64": true},
+			platforms: []string{"amd64", "arm64"},
+		},
+		{
+			name:      "platforms not pulled are left out",
+			present:   map[string]bool{"arm64": true},
+			platforms: []string{"arm64"},
+		},
+		{
+			name:    "no platform pulled",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			desc := f.index(tt.present)
+			converted, err := Convert(f.ctx, f.store, desc, Options{Compression: Gzip})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var idx index
+			data, err := content.ReadBlob(f.ctx, f.store, converted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, &idx); err != nil {
+				t.Fatal(err)
+			}
+			var platforms []string
+			for _, m := range idx.Manifests {
+				platforms = append(platforms, m.Platform.Architecture)
+				if _, err := f.store.Info(f.ctx, m.Digest); err != nil {
+					t.Errorf("manifest of %s: %v", m.Platform.Architecture, err)
+				}
+			}
+			if len(platforms) != len(tt.platforms) {
+				t.Fatalf("converted platforms %v, expected %v", platforms, tt.platforms)
+			}
+			for i := range platforms {
+				if platforms[i] != tt.platforms[i] {
+					t.Fatalf("converted platforms %v, expected %v", platforms, tt.platforms)
+				}
+			}
+		})
+	}
+}
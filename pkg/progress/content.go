@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"context"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+type contentStatusTracker struct {
+	ctx   context.Context
+	store content.Store
+}
+
+// NewContentStatusTracker returns a read-only StatusTracker that reports the status of refs, as generated by
+// remotes.MakeRefKey, from the active ingests of the content store. Refs that are not being ingested but whose digest
+// is present in the store are reported as complete.
+func NewContentStatusTracker(ctx context.Context, store content.Store) docker.StatusTracker {
+	return &contentStatusTracker{
+		ctx:   ctx,
+		store: store,
+	}
+}
+
+func (t *contentStatusTracker) GetStatus(ref string) (docker.Status, error) {
+	status, err := t.store.Status(t.ctx, ref)
+	if err == nil {
+		return docker.Status{Status: status}, nil
+	}
+	if !errdefs.IsNotFound(err) {
+		return docker.Status{}, err
+	}
+	parts := strings.SplitN(ref, "-", 2)
+	if len(parts) != 2 {
+		return docker.Status{}, err
+	}
+	dgst, perr := digest.Parse(parts[1])
+	if perr != nil {
+		return docker.Status{}, err
+	}
+	info, err := t.store.Info(t.ctx, dgst)
+	if err != nil {
+		return docker.Status{}, errors.Wrapf(err, "status for ref %v", ref)
+	}
+	return docker.Status{
+		Status: content.Status{
+			Ref:       ref,
+			Offset:    info.Size,
+			Total:     info.Size,
+			Expected:  dgst,
+			StartedAt: info.CreatedAt,
+			UpdatedAt: info.UpdatedAt,
+		},
+	}, nil
+}
+
+func (t *contentStatusTracker) SetStatus(_ string, _ docker.Status) {
+	// status is sourced from the content store
+}
@@ -13,11 +13,12 @@ import (
 
 type Tracker interface {
 	Add(ref string)
+	Update(ref, status string)
 	Status() <-chan []imagesv1.ImageStatus
 }
 
 type tracker struct {
-	*pushjobs
+	*jobs
 	status chan []imagesv1.ImageStatus
 }
 
@@ -25,9 +26,19 @@ func (t *tracker) Status() <-chan []imagesv1.ImageStatus {
 	return t.status
 }
 
+// NewTracker returns a Tracker reporting the progress of uploads, a.k.a. pushes.
 func NewTracker(ctx context.Context, statusTracker docker.StatusTracker) Tracker {
-	ongoing := newPushJobs(statusTracker)
+	return newTracker(ctx, newJobs(statusTracker, "uploading"))
+}
+
+// NewPullTracker returns a Tracker reporting the progress of downloads, a.k.a. pulls.
+func NewPullTracker(ctx context.Context, statusTracker docker.StatusTracker) Tracker {
+	return newTracker(ctx, newJobs(statusTracker, "downloading"))
+}
 
+// newTracker reports the status of the jobs every 100ms until the context is done, at which point the final status is
+// reported and the status channel closed. The status channel must be drained.
+func newTracker(ctx context.Context, ongoing *jobs) Tracker {
 	var (
 		result = make(chan []imagesv1.ImageStatus)
 	)
@@ -43,32 +54,38 @@ func NewTracker(ctx context.Context, statusTracker docker.StatusTracker) Tracker
 			case <-ticker.C:
 				result <- ongoing.status()
 			case <-ctx.Done():
+				// report the final status
+				result <- ongoing.status()
 				return
 			}
 		}
 	}()
 
 	return &tracker{
-		pushjobs: ongoing,
-		status:   result,
+		jobs:   ongoing,
+		status: result,
 	}
 }
 
-type pushjobs struct {
+type jobs struct {
 	jobs    map[string]struct{}
 	ordered []string
+	phases  map[string]string
+	active  string
 	tracker docker.StatusTracker
 	mu      sync.Mutex
 }
 
-func newPushJobs(tracker docker.StatusTracker) *pushjobs {
-	return &pushjobs{
+func newJobs(tracker docker.StatusTracker, active string) *jobs {
+	return &jobs{
 		jobs:    make(map[string]struct{}),
+		phases:  make(map[string]string),
+		active:  active,
 		tracker: tracker,
 	}
 }
 
-func (j *pushjobs) Add(ref string) {
+func (j *jobs) Add(ref string) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
@@ -79,7 +96,20 @@ func (j *pushjobs) Add(ref string) {
 	j.jobs[ref] = struct{}{}
 }
 
-func (j *pushjobs) status() []imagesv1.ImageStatus {
+// Update overrides the reported status of a ref with an explicit phase, e.g. resolving or extracting. An empty status
+// clears the override.
+func (j *jobs) Update(ref, status string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if status == "" {
+		delete(j.phases, ref)
+	} else {
+		j.phases[ref] = status
+	}
+}
+
+func (j *jobs) status() []imagesv1.ImageStatus {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
@@ -104,9 +134,12 @@ func (j *pushjobs) status() []imagesv1.ImageStatus {
 					si.Status = "committing"
 				}
 			} else {
-				si.Status = "uploading"
+				si.Status = j.active
 			}
 		}
+		if phase, ok := j.phases[name]; ok {
+			si.Status = phase
+		}
 		statuses = append(statuses, si)
 	}
 
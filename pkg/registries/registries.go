@@ -0,0 +1,369 @@
+package registries
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/auth"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+// Registry is the registries.yaml configuration shared with k3s, see
+// https://rancher.com/docs/k3s/latest/en/installation/private-registry/
+type Registry struct {
+	// Mirrors are namespace to mirror mapping for all namespaces.
+	Mirrors map[string]Mirror `json:"mirrors"`
+	// Configs are configs for each registry. The key is the FDQN or IP of the registry.
+	Configs map[string]RegistryConfig `json:"configs"`
+}
+
+// Mirror contains the config related to the registry mirror
+type Mirror struct {
+	// Endpoints are endpoints for a namespace. CRI plugin will try the endpoints one by one until a working one is
+	// found. The endpoint must be a valid url with host specified. The scheme, host and path from the endpoint URL
+	// will be used.
+	Endpoints []string `json:"endpoint"`
+}
+
+// RegistryConfig contains configuration used to communicate with the registry.
+type RegistryConfig struct {
+	// Auth contains information to authenticate to the registry.
+	Auth *AuthConfig `json:"auth"`
+	// TLS is a pair of CA/Cert/Key which then are used when creating the transport that communicates with the
+	// registry.
+	TLS *TLSConfig `json:"tls"`
+}
+
+// AuthConfig contains the config related to authentication to a specific registry
+type AuthConfig struct {
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	Auth          string `json:"auth"`
+	IdentityToken string `json:"identity_token"`
+}
+
+// TLSConfig contains the CA/Cert/Key used for a registry
+type TLSConfig struct {
+	CAFile             string `json:"ca_file"`
+	CertFile           string `json:"cert_file"`
+	KeyFile            string `json:"key_file"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// Load the registry configuration from the file, a missing file yields an empty configuration.
+func Load(path string) (*Registry, error) {
+	registry := &Registry{}
+	if path == "" {
+		return registry, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, registry); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	return registry, nil
+}
+
+// CredentialsFunc returns the username and secret for a host
+type CredentialsFunc func(host string) (string, string, error)
+
+// TokenFunc returns the registry (bearer) token for a host, if any
+type TokenFunc func(host string) (string, error)
+
+// HostOptions configure the registry hosts
+type HostOptions struct {
+	// Credentials for hosts without configured auth, may be nil
+	Credentials CredentialsFunc
+	// Tokens are the registry tokens for hosts without configured auth, taking precedence over credentials, may be nil
+	Tokens TokenFunc
+	// Header is sent with every request
+	Header http.Header
+	// Transport wraps the transport of every host, may be nil
+	Transport func(http.RoundTripper) http.RoundTripper
+}
+
+// Hosts returns the registry hosts for the configured mirrors (falling back to the `*` mirror) followed by the
+// registry itself. Mirrors are only used for pulls and resolves, pushes go to the registry: by the endpoint of its own
+// mirror, if any, so that registries served over plain HTTP or with their own certificates can be pushed to, otherwise
+// by https. Credentials configured for a host take precedence over those of the options.
+func (r *Registry) Hosts(opts HostOptions) docker.RegistryHosts {
+	return func(host string) ([]docker.RegistryHost, error) {
+		var (
+			hosts  []docker.RegistryHost
+			pushed bool
+		)
+		mirror, ok := r.Mirrors[host]
+		if !ok {
+			mirror = r.Mirrors["*"]
+		}
+		for _, endpoint := range mirror.Endpoints {
+			u, err := parseEndpoint(endpoint)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid endpoint %q for mirror %s", endpoint, host)
+			}
+			rh, err := r.registryHost(u.Host, u, opts)
+			if err != nil {
+				return nil, err
+			}
+			rh.Capabilities = docker.HostCapabilityPull | docker.HostCapabilityResolve
+			if !pushed && (u.Host == host || u.Host == defaultHost(host)) {
+				rh.Capabilities |= docker.HostCapabilityPush
+				pushed = true
+			}
+			hosts = append(hosts, rh)
+		}
+		if pushed {
+			return hosts, nil
+		}
+
+		u := &url.URL{Scheme: "https", Host: defaultHost(host), Path: "/v2"}
+		rh, err := r.registryHost(host, u, opts)
+		if err != nil {
+			return nil, err
+		}
+		rh.Capabilities = docker.HostCapabilityPull | docker.HostCapabilityResolve | docker.HostCapabilityPush
+		return append(hosts, rh), nil
+	}
+}
+
+// registryHost returns the host of the endpoint for the registry of the name, configured as per the name, e.g.
+// docker.io, falling back to the host of the endpoint, e.g. registry-1.docker.io.
+func (r *Registry) registryHost(name string, u *url.URL, opts HostOptions) (docker.RegistryHost, error) {
+	config, configured := r.Configs[name]
+	if !configured {
+		config, configured = r.Configs[u.Host]
+	}
+	client := http.DefaultClient
+	if config.TLS != nil && u.Scheme == "https" {
+		tlsConfig, err := config.TLS.clientConfig()
+		if err != nil {
+			return docker.RegistryHost{}, errors.Wrapf(err, "invalid tls config for %s", name)
+		}
+		client = &http.Client{Transport: newTransport(tlsConfig)}
+	}
+	if opts.Transport != nil {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		client = &http.Client{Transport: opts.Transport(transport)}
+	}
+	credentials, tokens, header := opts.Credentials, opts.Tokens, opts.Header
+	var authorizer docker.Authorizer = docker.NewDockerAuthorizer(
+		docker.WithAuthClient(client),
+		docker.WithAuthHeader(header),
+		docker.WithAuthCreds(func(host string) (string, string, error) {
+			if host == u.Host && config.Auth != nil {
+				return config.Auth.credentials()
+			}
+			if c, ok := r.Configs[host]; ok && c.Auth != nil {
+				return c.Auth.credentials()
+			}
+			if credentials == nil {
+				return "", "", nil
+			}
+			return credentials(host)
+		}),
+	)
+	if tokens != nil && !configured {
+		authorizer = &tokenAuthorizer{
+			Authorizer: authorizer,
+			tokens:     tokens,
+		}
+	}
+	return docker.RegistryHost{
+		Client:     client,
+		Authorizer: authorizer,
+		Host:       u.Host,
+		Scheme:     u.Scheme,
+		Path:       u.Path,
+		Header:     header,
+	}, nil
+}
+
+// Files returns the TLS files (CAs, certificates and keys) referenced by the configuration
+func (r *Registry) Files() []string {
+	var files []string
+	seen := map[string]bool{}
+	for _, config := range r.Configs {
+		if config.TLS == nil {
+			continue
+		}
+		for _, file := range []string{config.TLS.CAFile, config.TLS.CertFile, config.TLS.KeyFile} {
+			if file != "" && !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// defaultHost returns the host of the api of the registry, which differs from its name for docker.io only
+func defaultHost(host string) string {
+	if host == "docker.io" {
+		return "registry-1.docker.io"
+	}
+	return host
+}
+
+// tokenAuthorizer sends the registry token for the host, if any, as a bearer token. A rejected registry token cannot
+// be refreshed. Hosts without a registry token are handled by the wrapped authorizer.
+type tokenAuthorizer struct {
+	docker.Authorizer
+	tokens TokenFunc
+}
+
+func (a *tokenAuthorizer) Authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.tokens(req.URL.Host)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return a.Authorizer.Authorize(ctx, req)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *tokenAuthorizer) AddResponses(ctx context.Context, responses []*http.Response) error {
+	last := responses[len(responses)-1]
+	token, err := a.tokens(last.Request.URL.Host)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		return errors.Wrapf(errdefs.ErrNotImplemented, "registry token rejected by %s", last.Request.URL.Host)
+	}
+	return a.Authorizer.AddResponses(ctx, responses)
+}
+
+func (a *AuthConfig) credentials() (string, string, error) {
+	return auth.Parse(&criv1.AuthConfig{
+		Username:      a.Username,
+		Password:      a.Password,
+		Auth:          a.Auth,
+		IdentityToken: a.IdentityToken,
+	}, "")
+}
+
+func (t *TLSConfig) clientConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		ca, err := ioutil.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.Errorf("no certificates found in %s", t.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// parseEndpoint parses the mirror endpoint, defaulting to https and appending the /v2 api path
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/v2") {
+		u.Path += "/v2"
+	}
+	return u, nil
+}
+
+// newTransport mirrors the settings of http.DefaultTransport
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+}
+
+// Login authenticates against the api of the registry host with the credentials, answering the (basic or token)
+// challenge of the registry as `docker login` does. The authorizer of the host is not used as the auth configured for
+// the registry would take precedence over the credentials being verified.
+func Login(ctx context.Context, host docker.RegistryHost, credentials CredentialsFunc) error {
+	host.Authorizer = docker.NewDockerAuthorizer(
+		docker.WithAuthClient(host.Client),
+		docker.WithAuthHeader(host.Header),
+		docker.WithAuthCreds(credentials),
+	)
+	u := url.URL{Scheme: host.Scheme, Host: host.Host, Path: host.Path + "/"}
+	for challenged := false; ; challenged = true {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return err
+		}
+		for k, v := range host.Header {
+			req.Header[k] = v
+		}
+		if err := host.Authorizer.Authorize(ctx, req); err != nil {
+			return errors.Wrapf(err, "failed to authenticate with %s", host.Host)
+		}
+		resp, err := host.Client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return nil
+		case resp.StatusCode == http.StatusUnauthorized && !challenged:
+			if err := host.Authorizer.AddResponses(ctx, []*http.Response{resp}); err != nil {
+				return errors.Wrapf(err, "failed to authenticate with %s", host.Host)
+			}
+		default:
+			return errors.Errorf("login to %s failed: %s", host.Host, resp.Status)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package registries
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/containerd/containerd/remotes/docker"
+)
+
+func TestHosts(t *testing.T) {
+	const (
+		pull = docker.HostCapabilityPull | docker.HostCapabilityResolve
+		push = pull | docker.HostCapabilityPush
+	)
+	type host struct {
+		URL          string
+		Capabilities docker.HostCapabilities
+	}
+	tests := []struct {
+		name     string
+		registry Registry
+		host     string
+		want     []host
+	}{
+		{
+			name: "no mirror",
+			host: "registry.local",
+			want: []host{{"https://registry.local/v2", push}},
+		},
+		{
+			name: "docker hub",
+			host: "docker.io",
+			want: []host{{"https://registry-1.docker.io/v2", push}},
+		},
+		{
+			name: "mirror of another host",
+			registry: Registry{Mirrors: map[string]Mirror{
+				"docker.io": {Endpoints: []string{"http://mirror.local:5000"}},
+			}},
+			host: "docker.io",
+			want: []host{
+				{"http://mirror.local:5000/v2", pull},
+				{"https://registry-1.docker.io/v2", push},
+			},
+		},
+		{
+			name: "mirror of the registry itself is pushed to",
+			registry: Registry{Mirrors: map[string]Mirror{
+				"registry.local:5000": {Endpoints: []string{"http://registry.local:5000"}},
+			}},
+			host: "registry.local:5000",
+			want: []host{{"http://registry.local:5000/v2", push}},
+		},
+		{
+			name: "mirror of docker hub by its api host",
+			registry: Registry{Mirrors: map[string]Mirror{
+				"docker.io": {Endpoints: []string{"https://mirror.local", "https://registry-1.docker.io"}},
+			}},
+			host: "docker.io",
+			want: []host{
+				{"https://mirror.local/v2", pull},
+				{"https://registry-1.docker.io/v2", push},
+			},
+		},
+		{
+			name: "wildcard mirror",
+			registry: Registry{Mirrors: map[string]Mirror{
+				"*": {Endpoints: []string{"https://mirror.local"}},
+			}},
+			host: "quay.io",
+			want: []host{
+				{"https://mirror.local/v2", pull},
+				{"https://quay.io/v2", push},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hosts, err := tt.registry.Hosts(HostOptions{})(tt.host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []host
+			for _, h := range hosts {
+				got = append(got, host{h.Scheme + "://" + h.Host + h.Path, h.Capabilities})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hosts of %s are %v, expected %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostsInvalidEndpoint(t *testing.T) {
+	registry := Registry{Mirrors: map[string]Mirror{
+		"docker.io": {Endpoints: []string{"://mirror"}},
+	}}
+	if _, err := registry.Hosts(HostOptions{})("docker.io"); err == nil {
+		t.Error("expected an error for the invalid endpoint")
+	}
+}
+
+func TestHostCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry Registry
+		host     string
+		want     string
+	}{
+		{
+			name: "configured by registry name",
+			registry: Registry{Configs: map[string]RegistryConfig{
+				"docker.io": {Auth: &AuthConfig{Username: "configured", Password: "secret"}},
+			}},
+			host: "docker.io",
+			want: "configured",
+		},
+		{
+			name: "configured by api host",
+			registry: Registry{Configs: map[string]RegistryConfig{
+				"registry-1.docker.io": {Auth: &AuthConfig{Username: "configured", Password: "secret"}},
+			}},
+			host: "docker.io",
+			want: "configured",
+		},
+		{
+			name: "from the options",
+			host: "docker.io",
+			want: "option",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hosts, err := tt.registry.Hosts(HostOptions{
+				Credentials: func(host string) (string, string, error) {
+					return "option", "secret", nil
+				},
+			})(tt.host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := hosts[len(hosts)-1]
+			// the credentials are only observable through the authorizer, as sent with the basic auth challenge
+			got, err := basicUser(h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("user for %s is %q, expected %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+// basicUser returns the user that the host authorizes as when challenged for basic auth
+func basicUser(h docker.RegistryHost) (string, error) {
+	ctx := context.Background()
+	u := h.Scheme + "://" + h.Host + h.Path + "/"
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	challenge := &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Header:     http.Header{"Www-Authenticate": []string{`Basic realm="test"`}},
+		Request:    req,
+	}
+	if err := h.Authorizer.AddResponses(ctx, []*http.Response{challenge}); err != nil {
+		return "", err
+	}
+	req, err = http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := h.Authorizer.Authorize(ctx, req); err != nil {
+		return "", err
+	}
+	user, _, _ := req.BasicAuth()
+	return user, nil
+}
+
+func TestFiles(t *testing.T) {
+	registry := Registry{Configs: map[string]RegistryConfig{
+		"a.local": {TLS: &TLSConfig{CAFile: "/etc/ssl/ca.pem", CertFile: "/etc/a/cert.pem", KeyFile: "/etc/a/key.pem"}},
+		"b.local": {TLS: &TLSConfig{CAFile: "/etc/ssl/ca.pem"}},
+		"c.local": {Auth: &AuthConfig{Username: "user"}},
+	}}
+	want := []string{"/etc/a/cert.pem", "/etc/a/key.pem", "/etc/ssl/ca.pem"}
+	if got := registry.Files(); !reflect.DeepEqual(got, want) {
+		t.Errorf("files are %v, expected %v", got, want)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if user, password, ok := req.BasicAuth(); ok && user == "user" && password == "secret" {
+			return
+		}
+		w.Header().Set("Www-Authenticate", `Basic realm="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		configured *AuthConfig
+		user       string
+		password   string
+		wantErr    bool
+	}{
+		{name: "valid credentials", user: "user", password: "secret"},
+		{name: "invalid credentials", user: "user", password: "wrong", wantErr: true},
+		{
+			name:       "invalid credentials of a registry with configured auth",
+			configured: &AuthConfig{Username: "user", Password: "secret"},
+			user:       "user",
+			password:   "wrong",
+			wantErr:    true,
+		},
+		{
+			name:       "valid credentials of a registry with other configured auth",
+			configured: &AuthConfig{Username: "other", Password: "wrong"},
+			user:       "user",
+			password:   "secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := Registry{
+				Mirrors: map[string]Mirror{u.Host: {Endpoints: []string{srv.URL}}},
+				Configs: map[string]RegistryConfig{u.Host: {Auth: tt.configured}},
+			}
+			hosts, err := registry.Hosts(HostOptions{})(u.Host)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = Login(context.Background(), hosts[0], func(string) (string, string, error) {
+				return tt.user, tt.password, nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("login returned error %v, expected an error: %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package registries
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	backoffInitial = 500 * time.Millisecond
+	backoffMax     = 30 * time.Second
+)
+
+// Backoff returns the exponential delay before the retry following the attempt (zero-based)
+func Backoff(attempt int) time.Duration {
+	delay := backoffInitial
+	for i := 0; i < attempt && delay < backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > backoffMax {
+		delay = backoffMax
+	}
+	return delay
+}
+
+// Retrying wraps the transport so that requests failing with a network error or a transient status (429, 5xx) are
+// retried with exponential backoff, up to the number of retries, other statuses (e.g. 401 or 404) failing at once.
+// Transfers resume rather than restart: the download of a blob interrupted mid-body continues with a range request from
+// the offset received and blob uploads are sent in chunks, the upload of a failed chunk resuming from the offset that
+// the registry acknowledged. Other requests with a body are only retried when the body can be replayed.
+func Retrying(retries int) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if retries <= 0 {
+			return next
+		}
+		return &retryingTransport{next: next, retries: retries, chunkSize: uploadChunkSize}
+	}
+}
+
+type retryingTransport struct {
+	next      http.RoundTripper
+	retries   int
+	chunkSize int
+}
+
+func (t *retryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isBlobUpload(req) {
+		return t.upload(req)
+	}
+	replayable := req.Body == nil || req.Body == http.NoBody
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if !replayable || attempt >= t.retries || !transient(resp, err) {
+			if err == nil && isBlobDownload(req, resp) {
+				resp.Body = &resumingReader{transport: t, req: req, body: resp.Body}
+			}
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := t.backoff(req, attempt, transientReason(resp, err)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff waits before the retry following the attempt, unless the request is done
+func (t *retryingTransport) backoff(req *http.Request, attempt int, reason interface{}) error {
+	delay := Backoff(attempt)
+	logrus.Debugf("registry: retrying %s %s in %s: %v", req.Method, req.URL, delay, reason)
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// isBlobDownload returns whether the response is the full body of a blob, which can be resumed with range requests.
+// Registries, e.g. Docker Hub, ECR or GCR, redirect blob downloads to their storage, whose URLs are not those of
+// the api, so the blob is told by the original request of the redirects.
+func isBlobDownload(req *http.Request, resp *http.Response) bool {
+	return req.Method == http.MethodGet && resp.StatusCode == http.StatusOK && req.Header.Get("Range") == "" &&
+		resp.Header.Get("Accept-Ranges") != "none" && strings.Contains(originalRequest(req).URL.Path, "/blobs/")
+}
+
+// originalRequest returns the request that the request was redirected from, if any, the request itself otherwise
+func originalRequest(req *http.Request) *http.Request {
+	for req.Response != nil && req.Response.Request != nil {
+		req = req.Response.Request
+	}
+	return req
+}
+
+// resumingReader reads the body of a blob, resuming with a range request from the offset read should the body fail
+type resumingReader struct {
+	transport *retryingTransport
+	req       *http.Request
+	body      io.ReadCloser
+	offset    int64
+	attempt   int
+}
+
+func (r *resumingReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+		if n > 0 {
+			// the failure recurs with the next read, once the bytes read are consumed
+			return n, nil
+		}
+		if r.attempt >= r.transport.retries || r.req.Context().Err() != nil {
+			return n, err
+		}
+		if rerr := r.resume(err); rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
+// resume the body from the offset read, failing unless the registry serves the range
+func (r *resumingReader) resume(cause interface{}) error {
+	for ; r.attempt < r.transport.retries; r.attempt++ {
+		if err := r.transport.backoff(r.req, r.attempt, cause); err != nil {
+			return err
+		}
+		req := r.req.Clone(r.req.Context())
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+		resp, err := r.transport.next.RoundTrip(req)
+		if err != nil {
+			cause = err
+			continue
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			if transient(resp, nil) {
+				cause = resp.Status
+				continue
+			}
+			return errors.Errorf("failed to resume %s at offset %d: %s", r.req.URL, r.offset, resp.Status)
+		}
+		r.body.Close()
+		r.body = resp.Body
+		r.attempt++
+		logrus.Debugf("registry: resumed %s at offset %d", r.req.URL, r.offset)
+		return nil
+	}
+	return errors.Errorf("failed to resume %s at offset %d: %v", r.req.URL, r.offset, cause)
+}
+
+func (r *resumingReader) Close() error {
+	return r.body.Close()
+}
+
+func transient(resp *http.Response, err error) bool {
+	if err != nil {
+		return err != context.Canceled && err != context.DeadlineExceeded
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func transientReason(resp *http.Response, err error) interface{} {
+	if err != nil {
+		return err
+	}
+	return resp.Status
+}
+
+// RateLimited wraps the transport so that request and response bodies are throttled by the limiter. The limiter is
+// meant to be shared by all hosts so as to cap the overall bandwidth.
+func RateLimited(limiter *rate.Limiter) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if limiter == nil {
+			return next
+		}
+		return &rateLimitedTransport{next: next, limiter: limiter}
+	}
+}
+
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(ctx)
+		req.Body = &rateLimitedReader{ctx: ctx, ReadCloser: req.Body, limiter: t.limiter}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &rateLimitedReader{ctx: ctx, ReadCloser: resp.Body, limiter: t.limiter}
+	return resp, nil
+}
+
+type rateLimitedReader struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if burst := r.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+// Counting wraps the transport so that the bytes of request bodies sent and response bodies received are reported to
+// the passed functions.
+func Counting(sent, received func(int)) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &countingTransport{next: next, sent: sent, received: received}
+	}
+}
+
+type countingTransport struct {
+	next           http.RoundTripper
+	sent, received func(int)
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &countingReader{ReadCloser: req.Body, count: t.sent}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingReader{ReadCloser: resp.Body, count: t.received}
+	return resp, nil
+}
+
+type countingReader struct {
+	io.ReadCloser
+	count func(int)
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.count(n)
+	}
+	return n, err
+}
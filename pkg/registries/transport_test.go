@@ -0,0 +1,248 @@
+package registries
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// faultyRegistry serves a blob and upload sessions, dropping the connection halfway through the first transfer of the
+// blob and of the chunk at uploadFault. Blob downloads are redirected to the storage with redirect.
+type faultyRegistry struct {
+	t           *testing.T
+	blob        []byte
+	uploadFault int64
+	redirect    bool
+
+	mu          sync.Mutex
+	uploaded    []byte
+	downloaded  bool
+	uploadFails bool
+	requests    []string
+}
+
+func (r *faultyRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req.Method+" "+req.Header.Get("Range")+req.Header.Get("Content-Range"))
+	switch {
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/blobs/sha256:") && r.redirect:
+		http.Redirect(w, req, "/storage/blob?signature=1", http.StatusTemporaryRedirect)
+	case req.Method == http.MethodGet && (strings.Contains(req.URL.Path, "/blobs/sha256:") || strings.HasPrefix(req.URL.Path, "/storage/")):
+		var offset int64
+		if rng := req.Header.Get("Range"); rng != "" {
+			offset, _ = strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"), 10, 64)
+			w.Header().Set("Content-Length", strconv.Itoa(len(r.blob)-int(offset)))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", strconv.Itoa(len(r.blob)))
+		}
+		if !r.downloaded {
+			r.downloaded = true
+			w.Write(r.blob[:len(r.blob)/2])
+			w.(http.Flusher).Flush()
+			r.drop(w)
+			return
+		}
+		w.Write(r.blob[offset:])
+	case req.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/test/blobs/uploads/1?_state=0")
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPatch:
+		var start, end int64
+		fmt.Sscanf(req.Header.Get("Content-Range"), "%d-%d", &start, &end)
+		if start != int64(len(r.uploaded)) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if start == r.uploadFault && !r.uploadFails {
+			r.uploadFails = true
+			chunk := make([]byte, (end-start+1)/2)
+			io.ReadFull(req.Body, chunk)
+			r.uploaded = append(r.uploaded, chunk...)
+			r.drop(w)
+			return
+		}
+		chunk, _ := ioutil.ReadAll(req.Body)
+		r.uploaded = append(r.uploaded, chunk...)
+		r.session(w, http.StatusAccepted)
+	case req.Method == http.MethodGet:
+		r.session(w, http.StatusNoContent)
+	case req.Method == http.MethodPut:
+		if req.URL.Query().Get("_state") != strconv.Itoa(len(r.uploaded)) {
+			r.t.Errorf("stale upload location %s", req.URL)
+		}
+		if d := digest.FromBytes(r.uploaded); d.String() != req.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *faultyRegistry) session(w http.ResponseWriter, status int) {
+	w.Header().Set("Location", fmt.Sprintf("/v2/test/blobs/uploads/1?_state=%d", len(r.uploaded)))
+	w.Header().Set("Range", fmt.Sprintf("0-%d", len(r.uploaded)-1))
+	w.WriteHeader(status)
+}
+
+// drop the connection
+func (r *faultyRegistry) drop(w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	conn.Close()
+}
+
+func testBlob() []byte {
+	blob := make([]byte, 10000)
+	for i := range blob {
+		blob[i] = byte(i * 7)
+	}
+	return blob
+}
+
+func TestRetryingResumesDownloads(t *testing.T) {
+	resume := fmt.Sprintf("GET bytes=%d-", len(testBlob())/2)
+	for _, test := range []struct {
+		name     string
+		redirect bool
+		expected []string
+	}{
+		{name: "from the registry", expected: []string{"GET ", resume}},
+		// the download resumes from the storage that it was redirected to
+		{name: "redirected to the storage", redirect: true, expected: []string{"GET ", "GET ", resume}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			registry := &faultyRegistry{t: t, blob: testBlob(), redirect: test.redirect}
+			server := httptest.NewServer(registry)
+			defer server.Close()
+
+			client := &http.Client{Transport: Retrying(3)(http.DefaultTransport)}
+			resp, err := client.Get(server.URL + "/v2/test/blobs/" + digest.FromBytes(registry.blob).String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, registry.blob) {
+				t.Fatalf("downloaded %d bytes differing from the blob", len(data))
+			}
+			if fmt.Sprint(registry.requests) != fmt.Sprint(test.expected) {
+				t.Fatalf("expected requests %v, got %v", test.expected, registry.requests)
+			}
+		})
+	}
+}
+
+func TestRetryingResumesUploads(t *testing.T) {
+	registry := &faultyRegistry{t: t, blob: testBlob(), uploadFault: 4096}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryingTransport{next: http.DefaultTransport, retries: 3, chunkSize: 4096}}
+	resp, err := client.Post(server.URL+"/v2/test/blobs/uploads/", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	location, err := resp.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := location.Query()
+	query.Set("digest", digest.FromBytes(registry.blob).String())
+	location.RawQuery = query.Encode()
+
+	// the blob is piped, as by containerd, so the request cannot be replayed
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(func() error {
+			_, err := pw.Write(registry.blob)
+			return err
+		}())
+	}()
+	req, err := http.NewRequest(http.MethodPut, location.String(), pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.ContentLength = int64(len(registry.blob))
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected %d, got %s", http.StatusCreated, resp.Status)
+	}
+	if !bytes.Equal(registry.uploaded, registry.blob) {
+		t.Fatalf("uploaded %d bytes differing from the blob", len(registry.uploaded))
+	}
+	expected := []string{"POST ", "PATCH 0-4095", "PATCH 4096-8191", "GET ", "PATCH 6144-8191", "PATCH 8192-9999", "PUT "}
+	if fmt.Sprint(registry.requests) != fmt.Sprint(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, registry.requests)
+	}
+}
+
+func TestRetryingStatuses(t *testing.T) {
+	for _, test := range []struct {
+		statuses []int
+		expected int
+	}{
+		{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, expected: http.StatusOK},
+		{statuses: []int{http.StatusTooManyRequests, http.StatusOK}, expected: http.StatusOK},
+		{statuses: []int{http.StatusUnauthorized, http.StatusOK}, expected: http.StatusUnauthorized},
+		{statuses: []int{http.StatusForbidden, http.StatusOK}, expected: http.StatusForbidden},
+		{statuses: []int{http.StatusNotFound, http.StatusOK}, expected: http.StatusNotFound},
+	} {
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(test.statuses[requests])
+			requests++
+		}))
+		client := &http.Client{Transport: Retrying(3)(http.DefaultTransport)}
+		resp, err := client.Get(server.URL + "/v2/")
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.expected {
+			t.Errorf("%v: expected %d, got %d", test.statuses, test.expected, resp.StatusCode)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	for r, expected := range map[string]int64{
+		"":             0,
+		"0-0":          0,
+		"0-1023":       1024,
+		"bytes=0-4095": 4096,
+	} {
+		size, err := parseRange(r)
+		if err != nil {
+			t.Errorf("%q: %v", r, err)
+		} else if size != expected {
+			t.Errorf("%q: expected %d, got %d", r, expected, size)
+		}
+	}
+	if _, err := parseRange("invalid"); err == nil {
+		t.Error("expected an error for an invalid range")
+	}
+}
@@ -0,0 +1,202 @@
+package registries
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// uploadChunkSize is the size of the chunks that blobs are uploaded in, each buffered until acknowledged
+const uploadChunkSize = 16 << 20
+
+// isBlobUpload returns whether the request is the monolithic upload of a blob to an upload session, as pushed by
+// containerd: a PUT of the blob to the location of the session, naming its digest.
+func isBlobUpload(req *http.Request) bool {
+	return req.Method == http.MethodPut && req.URL.Query().Get("digest") != "" && req.Body != nil &&
+		req.Body != http.NoBody && strings.Contains(req.URL.Path, "/blobs/uploads/")
+}
+
+// upload the blob of the request in chunks, PATCHed to the upload session, before completing the session with a PUT
+// naming the digest. A chunk that fails is resumed from the offset that the registry acknowledged, as per the status
+// of the session. Registries that do not implement chunked uploads are sent the blob as is.
+func (t *retryingTransport) upload(req *http.Request) (*http.Response, error) {
+	defer req.Body.Close()
+	location := *req.URL
+	query := location.Query()
+	digest := query.Get("digest")
+	query.Del("digest")
+	location.RawQuery = query.Encode()
+
+	chunk := make([]byte, t.chunkSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(req.Body, chunk)
+		if n > 0 {
+			next, resp, perr := t.patch(req, &location, chunk[:n], offset)
+			if perr != nil {
+				return nil, perr
+			}
+			if resp != nil {
+				// the registry does not implement chunked uploads
+				return t.next.RoundTrip(monolithicUpload(req, io.MultiReader(bytes.NewReader(chunk[:n]), req.Body)))
+			}
+			location = *next
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query = location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+	for attempt := 0; ; attempt++ {
+		put := uploadRequest(req, http.MethodPut, &location, nil)
+		resp, err := t.next.RoundTrip(put)
+		if attempt >= t.retries || !transient(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := t.backoff(put, attempt, transientReason(resp, err)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// patch uploads the chunk of the blob at the offset, returning the location of the session for the next chunk. The
+// response is only returned, along with the unchanged location, when the registry rejects the first chunk as not
+// implemented, i.e. does not implement chunked uploads.
+func (t *retryingTransport) patch(req *http.Request, location *url.URL, chunk []byte, offset int64) (*url.URL, *http.Response, error) {
+	var start int64
+	for attempt := 0; ; attempt++ {
+		patch := uploadRequest(req, http.MethodPatch, location, chunk[start:])
+		patch.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset+start, offset+int64(len(chunk))-1))
+		resp, err := t.next.RoundTrip(patch)
+		if err == nil {
+			switch {
+			case resp.StatusCode == http.StatusAccepted:
+				resp.Body.Close()
+				next, err := sessionLocation(location, resp)
+				return next, nil, err
+			case offset == 0 && start == 0 && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented):
+				resp.Body.Close()
+				return location, resp, nil
+			case !transient(resp, nil):
+				resp.Body.Close()
+				return nil, nil, errors.Errorf("failed to upload to %s: %s", location.Host, resp.Status)
+			}
+			resp.Body.Close()
+		}
+		if attempt >= t.retries {
+			return nil, nil, errors.Errorf("failed to upload to %s at offset %d: %v", location.Host, offset+start,
+				transientReason(resp, err))
+		}
+		if err := t.backoff(patch, attempt, transientReason(resp, err)); err != nil {
+			return nil, nil, err
+		}
+		// resume from the offset acknowledged by the registry, which may have received part of the chunk
+		acknowledged, next, serr := t.uploadStatus(req, location)
+		if serr != nil {
+			logrus.Debugf("registry: failed to get the status of the upload to %s, resending the chunk: %v", location.Host, serr)
+			continue
+		}
+		if acknowledged < offset || acknowledged > offset+int64(len(chunk)) {
+			return nil, nil, errors.Errorf("failed to resume the upload to %s: %d bytes acknowledged, expected %d to %d",
+				location.Host, acknowledged, offset, offset+int64(len(chunk)))
+		}
+		start, location = acknowledged-offset, next
+		logrus.Debugf("registry: resuming the upload to %s at offset %d", location.Host, acknowledged)
+	}
+}
+
+// uploadStatus returns the number of bytes of the upload session received by the registry, along with its location
+func (t *retryingTransport) uploadStatus(req *http.Request, location *url.URL) (int64, *url.URL, error) {
+	resp, err := t.next.RoundTrip(uploadRequest(req, http.MethodGet, location, nil))
+	if err != nil {
+		return 0, nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return 0, nil, errors.Errorf("unexpected status: %s", resp.Status)
+	}
+	next, err := sessionLocation(location, resp)
+	if err != nil {
+		return 0, nil, err
+	}
+	size, err := parseRange(resp.Header.Get("Range"))
+	return size, next, err
+}
+
+// uploadRequest returns the request of the upload session at the location, with the headers of the original request
+func uploadRequest(req *http.Request, method string, location *url.URL, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	r.Method = method
+	r.URL = location
+	r.Host = ""
+	if location.Host != req.URL.Host {
+		// the credentials of the registry are not sent to the storage it redirects to
+		r.Header.Del("Authorization")
+	}
+	if body == nil {
+		r.Body, r.GetBody, r.ContentLength = http.NoBody, nil, 0
+		return r
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.GetBody = nil
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Type", "application/octet-stream")
+	return r
+}
+
+// monolithicUpload returns the original upload request with the body
+func monolithicUpload(req *http.Request, body io.Reader) *http.Request {
+	r := req.Clone(req.Context())
+	r.Body = ioutil.NopCloser(body)
+	r.GetBody = nil
+	return r
+}
+
+// sessionLocation returns the location of the upload session returned by the registry, relative to the current one
+func sessionLocation(current *url.URL, resp *http.Response) (*url.URL, error) {
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return current, nil
+	}
+	next, err := current.Parse(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid upload location %q", location)
+	}
+	return next, nil
+}
+
+// parseRange returns the number of bytes received of the range of an upload session, e.g. 0-1023 (or bytes=0-1023).
+// Registries report empty sessions as 0-0, as they do sessions of a single byte, which is resent.
+func parseRange(r string) (int64, error) {
+	r = strings.TrimPrefix(r, "bytes=")
+	if r == "" || r == "0-0" {
+		return 0, nil
+	}
+	parts := strings.SplitN(r, "-", 2)
+	if len(parts) != 2 {
+		return 0, errors.Errorf("invalid range %q", r)
+	}
+	end, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid range %q", r)
+	}
+	return end + 1, nil
+}
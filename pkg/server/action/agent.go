@@ -1,7 +1,41 @@
 package action
 
-import "github.com/rancher/k3c/pkg/server"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/server"
+	"google.golang.org/grpc"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
 
 type Agent struct {
 	server.Config
+	Insecure bool `usage:"Listen without TLS when no agent TLS directory is given, letting any client on the network manage images"`
+}
+
+type AgentHealth struct {
+	Port    int    `usage:"Port of the agent health service on the loopback interface" default:"1235"`
+	Service string `usage:"Health service to check, e.g. k3c.services.images.v1alpha1.Images for liveness (default is the overall health)"`
+}
+
+// Run checks the health service of the agent, failing unless serving
+func (s *AgentHealth) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("127.0.0.1:%d", s.Port), grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	res, err := healthv1.NewHealthClient(conn).Check(ctx, &healthv1.HealthCheckRequest{Service: s.Service})
+	if err != nil {
+		return err
+	}
+	if res.Status != healthv1.HealthCheckResponse_SERVING {
+		return errors.Errorf("agent health %q: %s", s.Service, res.Status)
+	}
+	return nil
 }
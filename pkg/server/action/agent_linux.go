@@ -4,22 +4,27 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"strconv"
+	"time"
 
-	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/api/events"
-	"github.com/containerd/containerd/content"
-	"github.com/containerd/containerd/errdefs"
-	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/typeurl"
 	"github.com/gogo/protobuf/types"
-	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/certs"
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/rancher/k3c/pkg/server"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func (s *Agent) Run(ctx context.Context) error {
@@ -29,31 +34,144 @@ func (s *Agent) Run(ctx context.Context) error {
 	}
 	defer backend.Close()
 
-	go s.syncImageContent(namespaces.WithNamespace(ctx, s.BuildkitNamespace), backend.Containerd)
-	go s.listenAndServe(ctx, backend)
+	var resync time.Duration
+	if s.ResyncInterval != "" {
+		if resync, err = time.ParseDuration(s.ResyncInterval); err != nil {
+			return errors.Wrapf(err, "invalid resync interval %q", s.ResyncInterval)
+		}
+	}
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
+	if s.SyncDeletePolicy == "" {
+		s.SyncDeletePolicy = server.DefaultSyncDelete
+	}
+	rules, err := server.LoadSyncRules(s.SyncRulesFile, server.SyncRule{
+		From:         s.BuildkitNamespace,
+		To:           []string{s.ImageNamespace},
+		DeletePolicy: s.SyncDeletePolicy,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to load the sync rules")
 	}
+
+	go s.syncImageContent(ctx, backend, rules)
+	go s.reconcileImages(ctx, backend, rules, resync)
+
+	// the agent exits should either of the listeners fail
+	eg, ctx := errgroup.WithContext(ctx)
+	hs := health.NewServer()
+	eg.Go(func() error {
+		return s.listenAndServe(ctx, backend, hs)
+	})
+	if s.HealthPort > 0 {
+		eg.Go(func() error {
+			return s.listenAndServeHealth(ctx, hs)
+		})
+	}
+	if s.MetricsPort > 0 {
+		go s.recordContentSize(ctx, backend, rules, resync)
+		eg.Go(func() error {
+			return s.listenAndServeMetrics(ctx)
+		})
+	}
+	go backend.ReportHealth(ctx, hs, 10*time.Second)
+	return eg.Wait()
 }
 
-func (s *Agent) listenAndServe(ctx context.Context, backend *server.Interface) error {
+func (s *Agent) listenAndServe(ctx context.Context, backend *server.Interface, hs *health.Server) error {
 	lc := &net.ListenConfig{}
 	listener, err := lc.Listen(ctx, "tcp", fmt.Sprintf("0.0.0.0:%d", s.AgentPort))
 	if err != nil {
-		return err
+		return errors.Wrap(err, "agent: failed to listen")
 	}
 	defer listener.Close()
 
-	server := grpc.NewServer()
+	var opts []grpc.ServerOption
+	if s.AgentTLSDir != "" {
+		tlsConfig, err := certs.ServerConfig(s.AgentTLSDir)
+		if err != nil {
+			return errors.Wrap(err, "failed to load the agent certificate")
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if s.Insecure {
+		logrus.Warnf("agent: listening on port %d without TLS, any client on the network may manage images", s.AgentPort)
+	} else {
+		return errors.New("agent: --agent-tls-dir is required, unless --insecure")
+	}
+	if s.Authorization {
+		if s.AgentTLSDir == "" {
+			return errors.New("agent: authorization requires TLS")
+		}
+		opts = append(opts, server.NewAuthorizer(backend).ServerOptions()...)
+	}
+	if s.MetricsPort > 0 {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(grpcprometheus.UnaryServerInterceptor),
+			grpc.ChainStreamInterceptor(grpcprometheus.StreamServerInterceptor),
+		)
+	}
+	server := grpc.NewServer(opts...)
 	imagesv1.RegisterImagesServer(server, backend)
-	defer server.Stop()
-	return server.Serve(listener)
+	healthv1.RegisterHealthServer(server, hs)
+	if s.MetricsPort > 0 {
+		grpcprometheus.EnableHandlingTimeHistogram()
+		grpcprometheus.Register(server)
+	}
+	return serve(ctx, server, listener)
+}
+
+// listenAndServeMetrics serves the Prometheus metrics of the agent
+func (s *Agent) listenAndServeMetrics(ctx context.Context) error {
+	lc := &net.ListenConfig{}
+	listener, err := lc.Listen(ctx, "tcp", net.JoinHostPort(s.MetricsAddress, strconv.Itoa(s.MetricsPort)))
+	if err != nil {
+		return errors.Wrap(err, "agent: failed to listen for metrics")
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	if err := server.Serve(listener); err != http.ErrServerClosed {
+		return errors.Wrapf(err, "agent: failed to serve metrics on %s", listener.Addr())
+	}
+	return ctx.Err()
 }
 
-func (s *Agent) syncImageContent(ctx context.Context, ctr *containerd.Client) {
-	events, errors := ctr.EventService().Subscribe(ctx, `topic~="/images/"`)
+// listenAndServeHealth serves the health service, alone and without TLS, on the loopback interface for the probes
+func (s *Agent) listenAndServeHealth(ctx context.Context, hs *health.Server) error {
+	lc := &net.ListenConfig{}
+	listener, err := lc.Listen(ctx, "tcp", fmt.Sprintf("127.0.0.1:%d", s.HealthPort))
+	if err != nil {
+		return errors.Wrap(err, "agent: failed to listen for health checks")
+	}
+	defer listener.Close()
+
+	server := grpc.NewServer()
+	healthv1.RegisterHealthServer(server, hs)
+	return serve(ctx, server, listener)
+}
+
+// serve the listener until the context is done
+func serve(ctx context.Context, server *grpc.Server, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+	if err := server.Serve(listener); err != nil {
+		return errors.Wrapf(err, "agent: failed to serve on %s", listener.Addr())
+	}
+	return ctx.Err()
+}
+
+// syncImageContent syncs the images of the namespaces of the rules as their events are received
+func (s *Agent) syncImageContent(ctx context.Context, backend *server.Interface, rules *server.SyncRules) {
+	sources := map[string]bool{}
+	for _, ns := range rules.Sources() {
+		sources[ns] = true
+	}
+	events, errors := backend.Containerd.EventService().Subscribe(ctx, `topic~="/images/"`)
 	for {
 		select {
 		case <-ctx.Done():
@@ -67,17 +185,40 @@ func (s *Agent) syncImageContent(ctx context.Context, ctr *containerd.Client) {
 			if !ok {
 				return
 			}
-			if evt.Namespace != s.BuildkitNamespace {
+			if !sources[evt.Namespace] {
 				continue
 			}
-			if err := handleImageEvent(ctx, ctr, evt.Event); err != nil {
+			if err := s.handleImageEvent(namespaces.WithNamespace(ctx, evt.Namespace), backend, rules, evt.Namespace, evt.Event); err != nil {
 				logrus.Errorf("sync-image-content: handling %#v returned %v", evt, err)
 			}
 		}
 	}
 }
 
-func handleImageEvent(ctx context.Context, ctr *containerd.Client, any *types.Any) error {
+// reconcileImages syncs the images built (or deleted) while the events were not watched, at startup and then at the
+// interval
+func (s *Agent) reconcileImages(ctx context.Context, backend *server.Interface, rules *server.SyncRules, interval time.Duration) {
+	for {
+		for i := range rules.Rules {
+			rule := &rules.Rules[i]
+			for _, to := range rule.To {
+				if _, err := backend.ReconcileImages(ctx, rule, to); err != nil {
+					logrus.Errorf("reconcile-images: %v", err)
+				}
+			}
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (s *Agent) handleImageEvent(ctx context.Context, backend *server.Interface, rules *server.SyncRules, ns string, any *types.Any) error {
 	evt, err := typeurl.UnmarshalAny(any)
 	if err != nil {
 		return errors.Wrap(err, "failed to unmarshal any")
@@ -86,70 +227,94 @@ func handleImageEvent(ctx context.Context, ctr *containerd.Client, any *types.An
 	switch e := evt.(type) {
 	case *events.ImageCreate:
 		logrus.Debugf("image-create: %s", e.Name)
-		return copyImageContent(ctx, ctr, e.Name, func(x context.Context, s images.Store, i images.Image) error {
-			_, err := s.Create(x, i)
-			if errdefs.IsAlreadyExists(err) {
-				_, err = s.Update(x, i)
-			}
-			return err
-		})
+		if ns == s.BuildkitNamespace {
+			s.recordBuild(ctx, backend, e.Name)
+		}
+		return s.syncImage(ctx, backend, rules, ns, e.Name)
 	case *events.ImageUpdate:
 		logrus.Debugf("image-update: %s", e.Name)
-		return copyImageContent(ctx, ctr, e.Name, func(x context.Context, s images.Store, i images.Image) error {
-			_, err := s.Create(x, i)
-			if errdefs.IsAlreadyExists(err) {
-				_, err = s.Update(x, i)
+		if ns == s.BuildkitNamespace {
+			s.recordBuild(ctx, backend, e.Name)
+		}
+		return s.syncImage(ctx, backend, rules, ns, e.Name)
+	case *events.ImageDelete:
+		logrus.Debugf("image-delete: %s", e.Name)
+		var failures []error
+		for _, rule := range rules.Rules {
+			if rule.From != ns || !rule.MatchesName(e.Name) {
+				continue
 			}
-			return err
-		})
+			for _, to := range rule.To {
+				if err := backend.DeleteImageCopy(ctx, ns, to, e.Name, rule.DeletePolicy); err != nil {
+					failures = append(failures, err)
+				}
+			}
+		}
+		return syncErrors(failures)
 	}
 
 	return nil
 }
 
-func copyImageContent(ctx context.Context, ctr *containerd.Client, name string, fn func(context.Context, images.Store, images.Image) error) error {
-	imageStore := ctr.ImageService()
-	img, err := imageStore.Get(ctx, name)
-	if err != nil {
-		return err
+// recordContentSize sizes the content of the namespaces of the rules for the metrics at the interval of the
+// reconciliation, or the default one should it be disabled.
+func (s *Agent) recordContentSize(ctx context.Context, backend *server.Interface, rules *server.SyncRules, interval time.Duration) {
+	if interval <= 0 {
+		interval, _ = time.ParseDuration(server.DefaultResync)
 	}
-	contentStore := ctr.ContentStore()
-	toCtx := namespaces.WithNamespace(ctx, "k8s.io")
-	handler := images.Handlers(images.ChildrenHandler(contentStore), copyImageContentFunc(toCtx, contentStore, img))
-	if err = images.Walk(ctx, handler, img.Target); err != nil {
-		return err
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		backend.RecordContentSize(ctx, rules.Namespaces()...)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
-	return fn(toCtx, imageStore, img)
 }
 
-func copyImageContentFunc(toCtx context.Context, contentStore content.Store, img images.Image) images.HandlerFunc {
-	return func(fromCtx context.Context, desc ocispec.Descriptor) (children []ocispec.Descriptor, err error) {
-		logrus.Debugf("copy-image-content: media-type=%v, digest=%v", desc.MediaType, desc.Digest)
-		info, err := contentStore.Info(fromCtx, desc.Digest)
-		if err != nil {
-			return children, err
-		}
-		ra, err := contentStore.ReaderAt(fromCtx, desc)
-		if err != nil {
-			return children, err
-		}
-		defer ra.Close()
-		wopts := []content.WriterOpt{content.WithRef(img.Name)}
-		if _, err := contentStore.Info(toCtx, desc.Digest); errdefs.IsNotFound(err) {
-			// if the image does not already exist in the target namespace we supply the descriptor here so as to
-			// ensure that it is created with proper size information. if the image already exist the size for the digest
-			// for the to-be updated image is sourced from what is passed to content.Copy
-			wopts = append(wopts, content.WithDescriptor(desc))
-		}
-		w, err := contentStore.Writer(toCtx, wopts...)
-		if err != nil {
-			return children, err
+// recordBuild records the metrics of the build of the image, failing which is not an error of its sync
+func (s *Agent) recordBuild(ctx context.Context, backend *server.Interface, name string) {
+	if s.MetricsPort <= 0 {
+		return
+	}
+	if err := backend.RecordBuild(ctx, name); err != nil {
+		logrus.Warnf("metrics: failed to record the build of %s: %v", name, err)
+	}
+}
+
+// syncImage copies the image to the namespaces of the rules matching it, other than the namespace it was synced from
+func (s *Agent) syncImage(ctx context.Context, backend *server.Interface, rules *server.SyncRules, ns, name string) error {
+	img, err := backend.Containerd.ImageService().Get(namespaces.WithNamespace(ctx, ns), name)
+	if err != nil {
+		return err
+	}
+	var failures []error
+	for _, rule := range rules.Rules {
+		if rule.From != ns || !rule.Matches(img.Name, img.Labels) {
+			continue
 		}
-		defer w.Close()
-		err = content.Copy(toCtx, w, content.NewReader(ra), desc.Size, desc.Digest, content.WithLabels(info.Labels))
-		if err != nil && errdefs.IsAlreadyExists(err) {
-			return children, nil
+		for _, to := range rule.To {
+			if img.Labels[server.SyncedFromLabel] == to {
+				continue
+			}
+			err := backend.CopyImage(ctx, ns, to, name)
+			server.RecordImageSync(err)
+			if err != nil {
+				failures = append(failures, errors.Wrapf(err, "failed to copy to namespace %s", to))
+			}
 		}
-		return children, err
 	}
+	return syncErrors(failures)
+}
+
+func syncErrors(failures []error) error {
+	switch len(failures) {
+	case 0:
+		return nil
+	case 1:
+		return failures[0]
+	}
+	return errors.Errorf("%d failures, first: %v", len(failures), failures[0])
 }
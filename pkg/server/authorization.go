@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/k3c/pkg/client"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AuthorizationGroup is the api group of the virtual resource that callers are authorized against
+	AuthorizationGroup = "k3c.cattle.io"
+	// AuthorizationResource is the virtual resource that callers are authorized against
+	AuthorizationResource = "images"
+
+	// decisions are cached briefly so that streams of calls, e.g. pulling many images, don't each review the token
+	authorizationTTL = 10 * time.Second
+)
+
+// authorizationVerbs maps the RPCs to the verbs authorized on the images.k3c.cattle.io resource, RPCs that are not
+// mapped are denied. The version handshake only requires TLS, so that clients can tell incompatible agents apart from
+// denied calls.
+var authorizationVerbs = map[string]string{
+	"Version": "",
+	"Status":  "get",
+	"List":    "list",
+	"Events":  "watch",
+	"Info":    "get",
+	"Login":   "create",
+	"Pull":    "create",
+	"Push":    "create",
+	"Tag":     "create",
+	"Copy":    "create",
+	"Convert": "create",
+	"Remove":  "delete",
+}
+
+// Authorizer authenticates the bearer token of callers with a TokenReview and authorizes the verb of the RPC with a
+// SubjectAccessReview against the images.k3c.cattle.io resource in the namespace of the builder.
+type Authorizer struct {
+	backend   *Interface
+	decisions sync.Map
+}
+
+type authorizationDecision struct {
+	user    *authnv1.UserInfo
+	err     error
+	expires time.Time
+}
+
+type callerKey struct{}
+
+// caller returns the user of the call, as authenticated by the authorizer, if any
+func caller(ctx context.Context) (*authnv1.UserInfo, bool) {
+	user, ok := ctx.Value(callerKey{}).(*authnv1.UserInfo)
+	return user, ok
+}
+
+// NewAuthorizer returns the authorizer of calls to the backend
+func NewAuthorizer(backend *Interface) *Authorizer {
+	return &Authorizer{backend: backend}
+}
+
+// ServerOptions returns the interceptors authorizing unary and streaming calls
+func (a *Authorizer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx, err := a.authorize(ctx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := a.authorize(ss.Context(), info.FullMethod)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+// callerStream is the server stream of an authorized call, its context carrying the caller
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize the call, returning the context carrying the authenticated caller
+func (a *Authorizer) authorize(ctx context.Context, method string) (context.Context, error) {
+	verb, ok := authorizationVerbs[path.Base(method)]
+	if !ok {
+		return ctx, status.Errorf(codes.PermissionDenied, "%s is not authorized", method)
+	}
+	if verb == "" {
+		return ctx, nil
+	}
+	token := bearerToken(ctx)
+	if token == "" {
+		return ctx, status.Error(codes.Unauthenticated, "a kubernetes bearer token is required")
+	}
+	key := verb + "/" + token
+	if cached, ok := a.decisions.Load(key); ok {
+		if decision := cached.(authorizationDecision); time.Now().Before(decision.expires) {
+			return context.WithValue(ctx, callerKey{}, decision.user), decision.err
+		}
+		a.decisions.Delete(key)
+	}
+	user, err := a.review(ctx, token, verb)
+	if status.Code(err) == codes.Unauthenticated || status.Code(err) == codes.PermissionDenied || err == nil {
+		a.expire()
+		a.decisions.Store(key, authorizationDecision{user: user, err: err, expires: time.Now().Add(authorizationTTL)})
+	}
+	return context.WithValue(ctx, callerKey{}, user), err
+}
+
+// expire removes the expired decisions
+func (a *Authorizer) expire() {
+	now := time.Now()
+	a.decisions.Range(func(key, value interface{}) bool {
+		if now.After(value.(authorizationDecision).expires) {
+			a.decisions.Delete(key)
+		}
+		return true
+	})
+}
+
+func (a *Authorizer) review(ctx context.Context, token, verb string) (*authnv1.UserInfo, error) {
+	k8s := a.backend.Kubernetes
+	tr, err := k8s.Authentication.TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		logrus.Errorf("authorization: failed to review token: %v", err)
+		return nil, status.Error(codes.Unavailable, "failed to authenticate the bearer token")
+	}
+	if !tr.Status.Authenticated {
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	user := &tr.Status.User
+	return user, accessReview(ctx, k8s, user, authzv1.ResourceAttributes{
+		Namespace: k8s.Namespace,
+		Verb:      verb,
+		Group:     AuthorizationGroup,
+		Resource:  AuthorizationResource,
+	})
+}
+
+// accessReview reviews whether the user may access the resource with a SubjectAccessReview
+func accessReview(ctx context.Context, k8s *client.Interface, user *authnv1.UserInfo, attributes authzv1.ResourceAttributes) error {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	sar, err := k8s.Authorization.SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			Extra:              extra,
+			UID:                user.UID,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		logrus.Errorf("authorization: failed to review access of %s: %v", user.Username, err)
+		return status.Error(codes.Unavailable, "failed to authorize the bearer token")
+	}
+	if !sar.Status.Allowed {
+		resource := attributes.Resource
+		if attributes.Group != "" {
+			resource += "." + attributes.Group
+		}
+		return status.Errorf(codes.PermissionDenied, "%s cannot %s %s in namespace %s", user.Username, attributes.Verb,
+			resource, attributes.Namespace)
+	}
+	return nil
+}
+
+// bearerToken returns the bearer token of the authorization metadata of the call
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if strings.HasPrefix(value, "Bearer ") {
+			return strings.TrimPrefix(value, "Bearer ")
+		}
+	}
+	return ""
+}
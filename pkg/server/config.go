@@ -6,9 +6,13 @@ import (
 	"time"
 
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/identifiers"
+	"github.com/docker/go-units"
 	buildkit "github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/rancher/k3c/pkg/version"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
@@ -17,6 +21,13 @@ const (
 	defaultAgentPort     = 1233
 	defaultAgentImage    = "docker.io/rancher/k3c"
 	defaultBuildkitImage = "docker.io/moby/buildkit:v0.8.1"
+	defaultRegistries    = "/etc/rancher/k3s/registries.yaml"
+	defaultAgentTLSDir   = "/etc/rancher/k3c/tls"
+	defaultHealthPort    = 1235
+	defaultImageNs       = "k8s.io"
+	defaultResync        = "5m"
+	defaultIngestPoll    = "1s"
+	defaultSyncDelete    = SyncDeleteUnused
 
 //	defaultBuildkitPort      = 1234
 //	defaultBuildkitAddress   = "unix:///run/buildkit/buildkitd.sock"
@@ -28,6 +39,13 @@ var (
 	DefaultAgentPort     = defaultAgentPort
 	DefaultAgentImage    = defaultAgentImage
 	DefaultBuildkitImage = defaultBuildkitImage
+	DefaultRegistries    = defaultRegistries
+	DefaultAgentTLSDir   = defaultAgentTLSDir
+	DefaultHealthPort    = defaultHealthPort
+	DefaultImageNs       = defaultImageNs
+	DefaultResync        = defaultResync
+	DefaultIngestPoll    = defaultIngestPoll
+	DefaultSyncDelete    = defaultSyncDelete
 
 //	DefaultBuildkitPort      = defaultBuildkitPort
 //	DefaultBuildkitAddress   = defaultBuildkitAddress
@@ -37,13 +55,27 @@ var (
 )
 
 type Config struct {
-	AgentImage        string `usage:"Image to run the agent w/ missing tag inferred from version" default:"docker.io/rancher/k3c"`
-	AgentPort         int    `usage:"Port that the agent will listen on" default:"1233"`
-	BuildkitImage     string `usage:"BuildKit image for running buildkitd" default:"docker.io/moby/buildkit:v0.8.1"`
-	BuildkitNamespace string `usage:"BuildKit namespace in containerd (not 'k8s.io')" default:"buildkit"`
-	BuildkitPort      int    `usage:"BuildKit service port" default:"1234"`
-	BuildkitSocket    string `usage:"BuildKit socket address" default:"unix:///run/buildkit/buildkitd.sock"`
-	ContainerdSocket  string `usage:"Containerd socket address" default:"/run/k3s/containerd/containerd.sock"`
+	AgentImage         string `usage:"Image to run the agent w/ missing tag inferred from version" default:"docker.io/rancher/k3c"`
+	AgentPort          int    `usage:"Port that the agent will listen on" default:"1233"`
+	AgentTLSDir        string `name:"agent-tls-dir" usage:"Directory of the agent certificate (tls.crt, tls.key) and the CA (ca.crt) of client certificates, requiring mutual TLS (required by the agent unless --insecure, default for install is /etc/rancher/k3c/tls)"`
+	Authorization      bool   `usage:"Authenticate callers by their Kubernetes bearer token and authorize them with RBAC on images.k3c.cattle.io (requires TLS)"`
+	BuildkitImage      string `usage:"BuildKit image for running buildkitd" default:"docker.io/moby/buildkit:v0.8.1"`
+	BuildkitNamespace  string `usage:"BuildKit namespace in containerd (not 'k8s.io')" default:"buildkit"`
+	BuildkitPort       int    `usage:"BuildKit service port" default:"1234"`
+	BuildkitSocket     string `usage:"BuildKit socket address" default:"unix:///run/buildkit/buildkitd.sock"`
+	ContainerdSocket   string `usage:"Containerd socket address" default:"/run/k3s/containerd/containerd.sock"`
+	HealthPort         int    `usage:"Port of the agent health service on the loopback interface, without TLS, for probes (0 disables)" default:"1235"`
+	ImageNamespace     string `usage:"Containerd namespace of the images managed by the agent, those of the CRI namespace are managed via the CRI and those of others via containerd directly" default:"k8s.io"`
+	IngestPollInterval string `usage:"Interval that the content ingests are polled at for the content events, as containerd does not publish them" default:"1s"`
+	LimitRate          string `usage:"Limit the bandwidth of registry transfers in bytes per second, e.g. 10M (default is unlimited)"`
+	MetricsAddress     string `usage:"Address that the agent serves Prometheus metrics on, set by install to the address of the node" default:"127.0.0.1"`
+	MetricsPort        int    `usage:"Port that the agent serves Prometheus metrics on at /metrics (0 disables)"`
+	RegistriesFile     string `usage:"Registries configuration (mirrors, TLS and auth) shared with k3s" default:"/etc/rancher/k3s/registries.yaml"`
+	ResyncInterval     string `usage:"Interval of the reconciliation of the images synced between containerd namespaces, besides at startup (0 disables)" default:"5m"`
+	Retries            int    `usage:"Retries, with exponential backoff, of failed registry requests and blob transfers" default:"3"`
+	SyncDeletePolicy   string `usage:"Policy for the copies of images deleted from the namespace they were synced from, unless set by the sync rules: keep, delete-unused (unless used by containers) or delete"`
+	Snapshotter        string `usage:"Snapshotter that pulled images are unpacked with (default is that of the CRI, overlayfs for other image namespaces)"`
+	SyncRulesFile      string `usage:"Rules (YAML) of the images synced between containerd namespaces, read by install into the builder-sync ConfigMap (default syncs the buildkit namespace to the image namespace)"`
 }
 
 func (c *Config) GetAgentImage() string {
@@ -69,8 +101,37 @@ func (c *Config) Interface(ctx context.Context, config *client.Config) (*Interfa
 	if err != nil {
 		return nil, err
 	}
+	if c.ImageNamespace == "" {
+		c.ImageNamespace = criNamespace
+	}
+	if err := identifiers.Validate(c.ImageNamespace); err != nil {
+		return nil, errors.Wrapf(err, "invalid image namespace %q", c.ImageNamespace)
+	}
 	server := Interface{
 		Kubernetes: k8s,
+		config:     c,
+		// images exported before the agent started are not counted as builds
+		lastBuild: time.Now(),
+	}
+	if c.IngestPollInterval == "" {
+		c.IngestPollInterval = DefaultIngestPoll
+	}
+	if server.ingestPoll, err = time.ParseDuration(c.IngestPollInterval); err != nil || server.ingestPoll <= 0 {
+		return nil, errors.Errorf("invalid ingest poll interval %q", c.IngestPollInterval)
+	}
+	if c.LimitRate != "" {
+		limit, err := units.FromHumanSize(c.LimitRate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate limit %q", c.LimitRate)
+		}
+		if limit > 0 {
+			// allow bursts of up to a second's worth, but no less than a typical read
+			burst := int(limit)
+			if burst < 32*1024 {
+				burst = 32 * 1024
+			}
+			server.limiter = rate.NewLimiter(rate.Limit(limit), burst)
+		}
 	}
 
 	server.Buildkit, err = buildkit.New(ctx, c.BuildkitSocket)
@@ -92,6 +153,9 @@ func (c *Config) Interface(ctx context.Context, config *client.Config) (*Interfa
 	}
 	server.RuntimeService = criv1.NewRuntimeServiceClient(conn)
 	server.ImageService = criv1.NewImageServiceClient(conn)
+	if c.Snapshotter == "" {
+		c.Snapshotter = server.defaultSnapshotter(ctx)
+	}
 
 	return &server, nil
 }
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
+	"github.com/rancher/k3c/pkg/auth"
+	"github.com/rancher/k3c/pkg/registries"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/kubernetes/pkg/credentialprovider"
+	"k8s.io/kubernetes/pkg/credentialprovider/secrets"
+)
+
+// credentials returns the credentials callback for the resolver. The first auth config passed with a request that
+// matches the host takes precedence over the registry credentials stored in-cluster via `k3c login`.
+func (i *Interface) credentials(authConfigs []*criv1.AuthConfig) (registries.CredentialsFunc, error) {
+	config, err := i.Kubernetes.RegistryAuth()
+	if err != nil {
+		return nil, err
+	}
+	keyring := &credentialprovider.BasicDockerKeyring{}
+	keyring.Add(config)
+	return func(host string) (string, string, error) {
+		for _, authConfig := range authConfigs {
+			username, secret, err := auth.Parse(authConfig, host)
+			if err != nil || username != "" || secret != "" {
+				return username, secret, err
+			}
+		}
+		if host == "registry-1.docker.io" {
+			host = "docker.io"
+		}
+		if found, ok := keyring.Lookup(host); ok {
+			return found[0].Username, found[0].Password, nil
+		}
+		return "", "", nil
+	}, nil
+}
+
+// tokens returns the registry token callback for the resolver, the first auth config with a registry token that
+// matches the host wins.
+func tokens(authConfigs []*criv1.AuthConfig) registries.TokenFunc {
+	return func(host string) (string, error) {
+		for _, authConfig := range authConfigs {
+			token, err := auth.Token(authConfig, host)
+			if err != nil || token != "" {
+				return token, err
+			}
+		}
+		return "", nil
+	}
+}
+
+// serviceAccountAuth returns the credentials for the image from the imagePullSecrets of the ServiceAccount, as
+// namespace/name or name in the k3c namespace, as the kubelet would for a pod running as that ServiceAccount. Without
+// a ServiceAccount the default ServiceAccount of the k3c namespace is used, if any. The ServiceAccounts of other
+// namespaces, and their secrets, are read with the bearer token of the caller, see callerCore, as the agent may only
+// read those of the k3c namespace.
+func (i *Interface) serviceAccountAuth(ctx context.Context, image, serviceAccount string) ([]*criv1.AuthConfig, error) {
+	namespace, name := i.Kubernetes.Namespace, serviceAccount
+	if parts := strings.SplitN(serviceAccount, "/", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	}
+	if name == "" {
+		name = "default"
+	}
+	getServiceAccount := func(name string) (*corev1.ServiceAccount, error) {
+		return i.Kubernetes.Core.ServiceAccount().Get(namespace, name, metav1.GetOptions{})
+	}
+	getSecret := func(name string) (*corev1.Secret, error) {
+		return i.Kubernetes.Core.Secret().Get(namespace, name, metav1.GetOptions{})
+	}
+	if namespace != i.Kubernetes.Namespace {
+		core, err := i.callerCore(ctx)
+		if err != nil {
+			return nil, err
+		}
+		getServiceAccount = func(name string) (*corev1.ServiceAccount, error) {
+			sa, err := core.ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+			return sa, callerError(err)
+		}
+		getSecret = func(name string) (*corev1.Secret, error) {
+			secret, err := core.Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+			return secret, callerError(err)
+		}
+	}
+	sa, err := getServiceAccount(name)
+	if apierr.IsNotFound(err) && serviceAccount == "" {
+		return nil, nil
+	}
+	if status.Code(err) == codes.PermissionDenied {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get service account %s/%s", namespace, name)
+	}
+	var pullSecrets []corev1.Secret
+	for _, ref := range sa.ImagePullSecrets {
+		secret, err := getSecret(ref.Name)
+		if apierr.IsNotFound(err) {
+			// the kubelet ignores missing pull secrets as well
+			logrus.Warnf("image pull secret %s/%s of service account %s not found", namespace, ref.Name, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pullSecrets = append(pullSecrets, *secret)
+	}
+	keyring, err := secrets.MakeDockerKeyring(pullSecrets, &credentialprovider.BasicDockerKeyring{})
+	if err != nil {
+		return nil, err
+	}
+	found, ok := keyring.Lookup(image)
+	if !ok {
+		return nil, nil
+	}
+	named, err := refdocker.ParseDockerRef(image)
+	if err != nil {
+		return nil, err
+	}
+	var auths []*criv1.AuthConfig
+	for _, a := range found {
+		auths = append(auths, &criv1.AuthConfig{
+			Username:      a.Username,
+			Password:      a.Password,
+			Auth:          a.Auth,
+			ServerAddress: "https://" + refdocker.Domain(named),
+			IdentityToken: a.IdentityToken,
+			RegistryToken: a.RegistryToken,
+		})
+	}
+	return auths, nil
+}
+
+// callerCore returns the core client of the caller, authenticated by its bearer token. Callers are only known to agents
+// authorizing them.
+func (i *Interface) callerCore(ctx context.Context) (corev1client.CoreV1Interface, error) {
+	user, ok := caller(ctx)
+	token := bearerToken(ctx)
+	if !ok || user == nil || token == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "the service accounts of namespaces other than %s require an agent with --authorization",
+			i.Kubernetes.Namespace)
+	}
+	return i.Kubernetes.CoreForToken(token)
+}
+
+// callerError returns the errors of the requests of the caller that it is forbidden as denying the call
+func callerError(err error) error {
+	if apierr.IsForbidden(err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/platforms"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/sirupsen/logrus"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+// criNamespace is the containerd namespace of the cri
+const criNamespace = "k8s.io"
+
+// imageNamespace returns the containerd namespace of the images managed by the agent
+func (i *Interface) imageNamespace() string {
+	if i.config == nil || i.config.ImageNamespace == "" {
+		return criNamespace
+	}
+	return i.config.ImageNamespace
+}
+
+// usesCRI returns whether the images are managed via the cri, i.e. are in its namespace. The images of other
+// namespaces are managed via containerd directly, mirroring what the cri reports.
+func (i *Interface) usesCRI() bool {
+	return i.imageNamespace() == criNamespace
+}
+
+// snapshotter returns the snapshotter that pulled images are unpacked with
+func (i *Interface) snapshotter() string {
+	if i.config == nil || i.config.Snapshotter == "" {
+		return containerd.DefaultSnapshotter
+	}
+	return i.config.Snapshotter
+}
+
+// defaultSnapshotter returns the snapshotter configured for the cri, so that the images pulled for it are not unpacked
+// again by the kubelet, falling back to the containerd default for other image namespaces or if the cri does not report
+// its configuration.
+func (i *Interface) defaultSnapshotter(ctx context.Context) string {
+	if !i.usesCRI() {
+		return containerd.DefaultSnapshotter
+	}
+	res, err := i.RuntimeService.Status(ctx, &criv1.StatusRequest{Verbose: true})
+	if err != nil {
+		logrus.Warnf("failed to query the cri for its snapshotter, defaulting to %s: %v", containerd.DefaultSnapshotter, err)
+		return containerd.DefaultSnapshotter
+	}
+	var config struct {
+		Containerd struct {
+			Snapshotter string `json:"snapshotter"`
+		} `json:"containerd"`
+	}
+	if err := json.Unmarshal([]byte(res.Info["config"]), &config); err != nil || config.Containerd.Snapshotter == "" {
+		logrus.Warnf("the cri did not report its snapshotter, defaulting to %s", containerd.DefaultSnapshotter)
+		return containerd.DefaultSnapshotter
+	}
+	return config.Containerd.Snapshotter
+}
+
+// listImages lists the images of the image namespace as the cri does
+func (i *Interface) listImages(ctx context.Context) ([]*criv1.Image, error) {
+	if i.usesCRI() {
+		res, err := i.ImageService.ListImages(ctx, &criv1.ListImagesRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return res.Images, nil
+	}
+	ctx = namespaces.WithNamespace(ctx, i.imageNamespace())
+	imgs, err := i.Containerd.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		list []*criv1.Image
+		byID = map[string]*criv1.Image{}
+	)
+	for _, img := range imgs {
+		id := i.imageID(ctx, img)
+		image, ok := byID[id]
+		if !ok {
+			size, _ := img.Size(ctx, i.Containerd.ContentStore(), platforms.Default())
+			image = &criv1.Image{Id: id, Size_: uint64(size)}
+			byID[id] = image
+			list = append(list, image)
+		}
+		// as with the cri, the references named by ID are not listed
+		if imageIDPattern.MatchString(img.Name) {
+			continue
+		}
+		named, err := refdocker.ParseDockerRef(img.Name)
+		if err != nil {
+			continue
+		}
+		if _, ok := named.(refdocker.Digested); ok {
+			image.RepoDigests = append(image.RepoDigests, named.String())
+		} else {
+			image.RepoTags = append(image.RepoTags, named.String())
+		}
+	}
+	return list, nil
+}
+
+// imageStatus returns the image of the name in the image namespace as the cri does, nil if there is none
+func (i *Interface) imageStatus(ctx context.Context, name string) (*criv1.Image, error) {
+	if i.usesCRI() {
+		res, err := i.ImageService.ImageStatus(ctx, &criv1.ImageStatusRequest{Image: &criv1.ImageSpec{Image: name}})
+		if err != nil {
+			return nil, err
+		}
+		return res.Image, nil
+	}
+	img, err := i.Containerd.ImageService().Get(namespaces.WithNamespace(ctx, i.imageNamespace()), name)
+	if errdefs.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	id := i.imageID(namespaces.WithNamespace(ctx, i.imageNamespace()), img)
+	list, err := i.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range list {
+		if image.Id == id {
+			return image, nil
+		}
+	}
+	return nil, nil
+}
+
+// removeImage removes the image, i.e. all of its references, from the image namespace
+func (i *Interface) removeImage(ctx context.Context, image *criv1.Image) error {
+	if i.usesCRI() {
+		_, err := i.ImageService.RemoveImage(ctx, &criv1.RemoveImageRequest{Image: &criv1.ImageSpec{Image: image.Id}})
+		return err
+	}
+	ctx = namespaces.WithNamespace(ctx, i.imageNamespace())
+	svc := i.Containerd.ImageService()
+	for _, name := range append(append([]string{image.Id}, image.RepoTags...), image.RepoDigests...) {
+		if err := svc.Delete(ctx, name); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageContainer returns the ID of a container using the image, if any
+func (i *Interface) imageContainer(ctx context.Context, image *criv1.Image) (string, error) {
+	if i.usesCRI() {
+		containers, err := i.RuntimeService.ListContainers(ctx, &criv1.ListContainersRequest{})
+		if err != nil {
+			return "", err
+		}
+		for _, container := range containers.Containers {
+			if container.ImageRef == image.Id || container.GetImage().GetImage() == image.Id {
+				return container.Id, nil
+			}
+		}
+		return "", nil
+	}
+	names := map[string]bool{image.Id: true}
+	for _, name := range append(append([]string{}, image.RepoTags...), image.RepoDigests...) {
+		names[name] = true
+	}
+	containers, err := i.Containerd.ContainerService().List(namespaces.WithNamespace(ctx, i.imageNamespace()))
+	if err != nil {
+		return "", err
+	}
+	for _, container := range containers {
+		if names[container.Image] {
+			return container.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// imageID returns the ID of the image as the cri does, i.e. the digest of its config for the default platform, falling
+// back to the digest of its target when the config of the platform is missing.
+func (i *Interface) imageID(ctx context.Context, img images.Image) string {
+	config, err := images.Config(ctx, i.Containerd.ContentStore(), img.Target, platforms.Default())
+	if err != nil {
+		return img.Target.Digest.String()
+	}
+	return config.Digest.String()
+}
+
+// imagesByIDPrefix returns an image record of each image, in the namespace of the context, of an ID with the prefix
+func (i *Interface) imagesByIDPrefix(ctx context.Context, prefix string) ([]images.Image, error) {
+	imgs, err := i.Containerd.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		list []images.Image
+		seen = map[string]bool{}
+	)
+	for _, img := range imgs {
+		id := i.imageID(ctx, img)
+		if seen[id] || !strings.HasPrefix(strings.TrimPrefix(id, "sha256:"), prefix) {
+			continue
+		}
+		seen[id] = true
+		list = append(list, img)
+	}
+	return list, nil
+}
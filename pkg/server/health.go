@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+const (
+	// HealthServiceImages is the health service of the images api, serving as long as the agent is, i.e. liveness
+	HealthServiceImages = "k3c.services.images.v1alpha1.Images"
+	// HealthServiceContainerd is the health service reporting the connectivity of containerd
+	HealthServiceContainerd = "containerd"
+	// HealthServiceCRI is the health service reporting the connectivity and readiness of the cri runtime
+	HealthServiceCRI = "cri"
+	// HealthServiceBuildkit is the health service reporting the connectivity of buildkit and its workers
+	HealthServiceBuildkit = "buildkit"
+
+	healthCheckTimeout = 5 * time.Second
+)
+
+// ReportHealth checks the backends at the interval, until the context is done, setting the status of the health
+// service of each. The overall health, i.e. readiness, is that of the agent itself, serving as long as it is: backends
+// that are down fail the calls that use them rather than taking the agent out of the builder Service, their state
+// being reported by their own health services and by `k3c info`.
+func (i *Interface) ReportHealth(ctx context.Context, hs *health.Server, interval time.Duration) {
+	hs.SetServingStatus("", healthv1.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(HealthServiceImages, healthv1.HealthCheckResponse_SERVING)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for service, err := range i.checkHealth(ctx) {
+			status := healthv1.HealthCheckResponse_SERVING
+			if err != nil {
+				logrus.Warnf("health: %s: %v", service, err)
+				status = healthv1.HealthCheckResponse_NOT_SERVING
+			}
+			hs.SetServingStatus(service, status)
+		}
+		select {
+		case <-ctx.Done():
+			hs.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHealth checks each of the backends, returning the error of each by health service
+func (i *Interface) checkHealth(ctx context.Context) map[string]error {
+	checks := map[string]func(context.Context) error{
+		HealthServiceContainerd: i.checkContainerd,
+		HealthServiceBuildkit:   i.checkBuildkit,
+	}
+	// the cri is not used, nor checked, when managing the images of another namespace
+	if i.usesCRI() {
+		checks[HealthServiceCRI] = i.checkCRI
+	}
+	errs := map[string]error{}
+	for service, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		errs[service] = check(checkCtx)
+		cancel()
+	}
+	return errs
+}
+
+func (i *Interface) checkContainerd(ctx context.Context) error {
+	_, err := i.Containerd.Version(ctx)
+	return err
+}
+
+func (i *Interface) checkCRI(ctx context.Context) error {
+	res, err := i.RuntimeService.Status(ctx, &criv1.StatusRequest{})
+	if err != nil {
+		return err
+	}
+	for _, condition := range res.GetStatus().GetConditions() {
+		if condition.Type == criv1.RuntimeReady && !condition.Status {
+			return errors.Errorf("runtime not ready: %s %s", condition.Reason, condition.Message)
+		}
+	}
+	return nil
+}
+
+func (i *Interface) checkBuildkit(ctx context.Context) error {
+	workers, err := i.Buildkit.ListWorkers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(workers) == 0 {
+		return errors.New("no workers")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/namespaces"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/converter"
+)
+
+// Convert image server-side impl, converts the content of the image and creates (or updates) the target image
+// referencing the converted content. Converting to zstd or eStargz produces images that can be pushed but not
+// necessarily run by the builder.
+func (i *Interface) Convert(ctx context.Context, req *imagesv1.ImageConvertRequest) (*imagesv1.ImageConvertResponse, error) {
+	ref, err := requestImage(req.GetImage())
+	if err != nil {
+		return nil, err
+	}
+	// containerd services require a namespace
+	ctx, done, err := i.Containerd.WithLease(namespaces.WithNamespace(ctx, i.imageNamespace()))
+	if err != nil {
+		return nil, err
+	}
+	defer done(ctx)
+	name, err := normalizeTag(req.Target)
+	if err != nil {
+		return nil, err
+	}
+	img, err := i.lookupImage(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	target, err := converter.Convert(ctx, i.Containerd.ContentStore(), img.Target, converter.Options{
+		Compression: req.Compression,
+		Force:       req.ForceCompression,
+		OCI:         req.Oci,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = i.createImageReference(ctx, name, target); err != nil {
+		return nil, err
+	}
+	return &imagesv1.ImageConvertResponse{
+		Image: name,
+	}, nil
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/identifiers"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// Copy image server-side impl, copies the content of the image to the target namespace (unless already present) and
+// creates (or updates) the target image there.
+func (i *Interface) Copy(ctx context.Context, req *imagesv1.ImageCopyRequest) (*imagesv1.ImageCopyResponse, error) {
+	ref, err := requestImage(req.GetImage())
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := i.copyNamespaces(req.FromNamespace, req.ToNamespace)
+	if err != nil {
+		return nil, err
+	}
+	img, err := i.lookupImage(namespaces.WithNamespace(ctx, from), ref)
+	if err != nil {
+		return nil, err
+	}
+	target := img.Name
+	if req.Target != "" {
+		if target, err = normalizeTag(req.Target); err != nil {
+			return nil, err
+		}
+	} else if imageIDPattern.MatchString(target) {
+		return nil, errors.Errorf("image %q has no name to copy to, specify the target", ref)
+	}
+	if err = i.copyImage(ctx, from, to, taggedImage(img, from), target); err != nil {
+		return nil, err
+	}
+	return &imagesv1.ImageCopyResponse{
+		Image: target,
+	}, nil
+}
+
+// CopyImage copies the named image, and its content, from one namespace to another, labeling the copy as synced from
+// the namespace. Images already of the same target in the other namespace are not updated, so that namespaces synced
+// both ways don't trigger each other.
+func (i *Interface) CopyImage(ctx context.Context, from, to, name string) error {
+	svc := i.Containerd.ImageService()
+	img, err := svc.Get(namespaces.WithNamespace(ctx, from), name)
+	if err != nil {
+		return err
+	}
+	existing, err := svc.Get(namespaces.WithNamespace(ctx, to), name)
+	if err == nil && existing.Target.Digest == img.Target.Digest {
+		logrus.Debugf("copy-image: %s is up to date in namespace %s", name, to)
+		return nil
+	}
+	return i.copyImage(ctx, from, to, syncedImage(img, from), name)
+}
+
+// copyImage copies the content of the image from one namespace to another under a lease, so that it cannot be
+// collected before the target image referencing it is created (or updated), which it is once all of the content has
+// been verified to be in the target namespace.
+func (i *Interface) copyImage(ctx context.Context, from, to string, img images.Image, target string) error {
+	toCtx, done, err := i.Containerd.WithLease(namespaces.WithNamespace(ctx, to))
+	if err != nil {
+		return err
+	}
+	defer done(toCtx)
+	if from != to {
+		store := i.Containerd.ContentStore()
+		fromCtx := namespaces.WithNamespace(ctx, from)
+		handler := images.Handlers(copyImageContentFunc(toCtx, store, img.Name), pulledChildrenHandler(store))
+		if err = images.Walk(fromCtx, handler, img.Target); err != nil {
+			return errors.Wrapf(err, "failed to copy content of %s from namespace %s to %s", img.Name, from, to)
+		}
+		handler = images.Handlers(verifyImageContentFunc(toCtx, store), pulledChildrenHandler(store))
+		if err = images.Walk(fromCtx, handler, img.Target); err != nil {
+			return errors.Wrapf(err, "failed to verify content of %s copied from namespace %s to %s", img.Name, from, to)
+		}
+	}
+	img.Name = target
+	svc := i.Containerd.ImageService()
+	if _, err = svc.Create(toCtx, img); errdefs.IsAlreadyExists(err) {
+		_, err = svc.Update(toCtx, img)
+	}
+	return err
+}
+
+func copyImageContentFunc(toCtx context.Context, contentStore content.Store, ref string) images.HandlerFunc {
+	return func(fromCtx context.Context, desc ocispec.Descriptor) (children []ocispec.Descriptor, err error) {
+		logrus.Debugf("copy-image-content: media-type=%v, digest=%v", desc.MediaType, desc.Digest)
+		info, err := contentStore.Info(fromCtx, desc.Digest)
+		if err != nil {
+			return children, errors.Wrapf(err, "content %s", desc.Digest)
+		}
+		// content already in the target namespace is reported as existing, having been added to the lease
+		w, err := contentStore.Writer(toCtx, content.WithRef(ref+"-"+desc.Digest.String()), content.WithDescriptor(desc))
+		if err != nil {
+			if errdefs.IsAlreadyExists(err) {
+				return children, nil
+			}
+			return children, err
+		}
+		defer w.Close()
+		ra, err := contentStore.ReaderAt(fromCtx, desc)
+		if err != nil {
+			return children, err
+		}
+		defer ra.Close()
+		err = content.Copy(toCtx, w, content.NewReader(ra), desc.Size, desc.Digest, content.WithLabels(info.Labels))
+		if err != nil && errdefs.IsAlreadyExists(err) {
+			return children, nil
+		}
+		return children, err
+	}
+}
+
+// verifyImageContentFunc verifies that the content in the source namespace is in the target namespace too
+func verifyImageContentFunc(toCtx context.Context, contentStore content.Store) images.HandlerFunc {
+	return func(fromCtx context.Context, desc ocispec.Descriptor) (children []ocispec.Descriptor, err error) {
+		info, err := contentStore.Info(toCtx, desc.Digest)
+		if err != nil {
+			return children, errors.Wrapf(err, "content %s", desc.Digest)
+		}
+		if info.Size != desc.Size {
+			return children, errors.Errorf("content %s has size %d, expected %d", desc.Digest, info.Size, desc.Size)
+		}
+		return children, nil
+	}
+}
+
+// pulledChildrenHandler returns the children of the content, leaving out the manifests of the platforms of an index
+// that were not pulled. The content of the platforms that were is required, as is that of an index with none of them.
+func pulledChildrenHandler(contentStore content.Store) images.HandlerFunc {
+	childrenHandler := images.ChildrenHandler(contentStore)
+	return func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		children, err := childrenHandler(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		switch desc.MediaType {
+		case images.MediaTypeDockerSchema2ManifestList, ocispec.MediaTypeImageIndex:
+		default:
+			return children, nil
+		}
+		var pulled []ocispec.Descriptor
+		for _, child := range children {
+			if _, err := contentStore.Info(ctx, child.Digest); err != nil {
+				if errdefs.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			pulled = append(pulled, child)
+		}
+		if len(pulled) == 0 && len(children) > 0 {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "content of no platform of %s", desc.Digest)
+		}
+		return pulled, nil
+	}
+}
+
+// copyNamespaces validates the namespaces to copy between, defaulting to the image namespace
+func (i *Interface) copyNamespaces(from, to string) (string, string, error) {
+	if from == "" {
+		from = i.imageNamespace()
+	}
+	if to == "" {
+		to = i.imageNamespace()
+	}
+	for _, ns := range []string{from, to} {
+		if err := identifiers.Validate(ns); err != nil {
+			return "", "", errors.Wrapf(err, "invalid namespace %q", ns)
+		}
+	}
+	return from, to, nil
+}
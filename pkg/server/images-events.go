@@ -0,0 +1,336 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/typeurl"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	EventTypeImage   = "image"
+	EventTypeContent = "content"
+	EventTypeBuild   = "build"
+
+	EventActionCreate   = "create"
+	EventActionUpdate   = "update"
+	EventActionDelete   = "delete"
+	EventActionIngest   = "ingest"
+	EventActionCommit   = "commit"
+	EventActionAbort    = "abort"
+	EventActionComplete = "complete"
+)
+
+// Events server-side impl
+func (i *Interface) Events(req *imagesv1.ImageEventsRequest, srv imagesv1.Images_EventsServer) error {
+	filter, err := parseEventFilters(req.Filters)
+	if err != nil {
+		return err
+	}
+	eg, ctx := errgroup.WithContext(srv.Context())
+	ch := make(chan *imagesv1.ImageEventsResponse)
+	// subscribe before replaying so that nothing falls between the cracks
+	eg.Go(func() error {
+		return i.watchEvents(ctx, ch)
+	})
+	if filter.types.matches(EventTypeContent) {
+		eg.Go(func() error {
+			return i.watchIngests(ctx, filter, ch)
+		})
+	}
+	eg.Go(func() error {
+		if req.Since != nil {
+			replay, err := i.replayEvents(ctx, filter, *req.Since)
+			if err != nil {
+				return err
+			}
+			for _, evt := range replay {
+				if err := srv.Send(evt); err != nil {
+					return err
+				}
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case evt := <-ch:
+				if !filter.matches(evt) {
+					continue
+				}
+				if err := srv.Send(evt); err != nil {
+					logrus.Debugf("events-send-error: %v", err)
+					return err
+				}
+			}
+		}
+	})
+	return eg.Wait()
+}
+
+// watchEvents translates containerd image and content events, forwarding them to the channel.
+func (i *Interface) watchEvents(ctx context.Context, ch chan<- *imagesv1.ImageEventsResponse) error {
+	envelopes, errs := i.Containerd.EventService().Subscribe(ctx, `topic~="/images/"`, `topic~="/content/"`)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-errs:
+			if !ok {
+				return eventsClosed(ctx)
+			}
+			return err
+		case env, ok := <-envelopes:
+			if !ok {
+				return eventsClosed(ctx)
+			}
+			evt, err := typeurl.UnmarshalAny(env.Event)
+			if err != nil {
+				logrus.Debugf("events-unmarshal-error: %v", err)
+				continue
+			}
+			var out []*imagesv1.ImageEventsResponse
+			switch e := evt.(type) {
+			case *events.ImageCreate:
+				out = append(out, i.imageEvent(env.Namespace, EventActionCreate, e.Name, e.Labels)...)
+			case *events.ImageUpdate:
+				out = append(out, i.imageEvent(env.Namespace, EventActionUpdate, e.Name, e.Labels)...)
+			case *events.ImageDelete:
+				out = append(out, i.imageEvent(env.Namespace, EventActionDelete, e.Name, nil)...)
+			case *events.ContentDelete:
+				out = append(out, &imagesv1.ImageEventsResponse{
+					Type:   EventTypeContent,
+					Action: EventActionDelete,
+					Name:   e.Digest.String(),
+				})
+			}
+			for _, o := range out {
+				o.Timestamp = env.Timestamp
+				o.Namespace = env.Namespace
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ch <- o:
+				}
+			}
+		}
+	}
+}
+
+// eventsClosed returns the error ending the stream of events once containerd closed the subscription, that of the
+// context when done, so that clients are not left waiting for events that will never come
+func eventsClosed(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return status.Error(codes.Unavailable, "the containerd event subscription was closed")
+}
+
+// imageEvent returns the event(s) for an image action, image creates/updates in the buildkit namespace are the result
+// of a build, unless tagged, copied or synced there by k3c, and so also produce a build completion event.
+func (i *Interface) imageEvent(ns, action, name string, labels map[string]string) []*imagesv1.ImageEventsResponse {
+	out := []*imagesv1.ImageEventsResponse{{
+		Type:       EventTypeImage,
+		Action:     action,
+		Name:       name,
+		Attributes: labels,
+	}}
+	if ns == i.config.BuildkitNamespace && action != EventActionDelete && builtImage(labels) {
+		out = append(out, &imagesv1.ImageEventsResponse{
+			Type:       EventTypeBuild,
+			Action:     EventActionComplete,
+			Name:       name,
+			Attributes: labels,
+		})
+	}
+	return out
+}
+
+// watchIngests polls the active content ingests, at the ingest poll interval, as containerd does not publish events for
+// them.
+func (i *Interface) watchIngests(ctx context.Context, filter *eventFilter, ch chan<- *imagesv1.ImageEventsResponse) error {
+	ingests := map[string]map[string]string{}
+	ticker := time.NewTicker(i.ingestPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		nss, err := i.eventNamespaces(ctx, filter)
+		if err != nil {
+			return err
+		}
+		var out []*imagesv1.ImageEventsResponse
+		for _, ns := range nss {
+			nctx := namespaces.WithNamespace(ctx, ns)
+			statuses, err := i.Containerd.ContentStore().ListStatuses(nctx)
+			if err != nil {
+				logrus.Debugf("events-list-statuses-error: %v", err)
+				continue
+			}
+			active := map[string]string{}
+			for _, s := range statuses {
+				active[s.Ref] = s.Expected.String()
+				if _, ok := ingests[ns][s.Ref]; !ok {
+					out = append(out, &imagesv1.ImageEventsResponse{
+						Timestamp: s.StartedAt,
+						Namespace: ns,
+						Type:      EventTypeContent,
+						Action:    EventActionIngest,
+						Name:      s.Ref,
+						Attributes: map[string]string{
+							"digest": s.Expected.String(),
+							"total":  strconv.FormatInt(s.Total, 10),
+						},
+					})
+				}
+			}
+			for ref, dgst := range ingests[ns] {
+				if _, ok := active[ref]; ok {
+					continue
+				}
+				action := EventActionAbort
+				if dgst != "" {
+					if _, err := i.Containerd.ContentStore().Info(nctx, digest.Digest(dgst)); err == nil {
+						action = EventActionCommit
+					} else if !errdefs.IsNotFound(err) {
+						logrus.Debugf("events-content-info-error: %v", err)
+					}
+				}
+				out = append(out, &imagesv1.ImageEventsResponse{
+					Timestamp:  time.Now(),
+					Namespace:  ns,
+					Type:       EventTypeContent,
+					Action:     action,
+					Name:       ref,
+					Attributes: map[string]string{"digest": dgst},
+				})
+			}
+			ingests[ns] = active
+		}
+		for _, o := range out {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case ch <- o:
+			}
+		}
+	}
+}
+
+// replayEvents synthesizes image events for images created or updated since the passed time.
+func (i *Interface) replayEvents(ctx context.Context, filter *eventFilter, since time.Time) ([]*imagesv1.ImageEventsResponse, error) {
+	nss, err := i.eventNamespaces(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var out []*imagesv1.ImageEventsResponse
+	for _, ns := range nss {
+		imgs, err := i.Containerd.ImageService().List(namespaces.WithNamespace(ctx, ns))
+		if err != nil {
+			return nil, err
+		}
+		for _, img := range imgs {
+			if img.UpdatedAt.Before(since) {
+				continue
+			}
+			action := EventActionUpdate
+			if !img.CreatedAt.Before(since) {
+				action = EventActionCreate
+			}
+			for _, evt := range i.imageEvent(ns, action, img.Name, img.Labels) {
+				evt.Timestamp = img.UpdatedAt
+				evt.Namespace = ns
+				if filter.matches(evt) {
+					out = append(out, evt)
+				}
+			}
+		}
+	}
+	sort.SliceStable(out, func(a, b int) bool {
+		return out[a].Timestamp.Before(out[b].Timestamp)
+	})
+	return out, nil
+}
+
+// eventNamespaces returns the containerd namespaces selected by the filter, defaulting to all of them.
+func (i *Interface) eventNamespaces(ctx context.Context, filter *eventFilter) ([]string, error) {
+	if len(filter.namespaces) > 0 {
+		return filter.namespaces.list(), nil
+	}
+	return i.Containerd.NamespaceService().List(ctx)
+}
+
+type eventFilterSet map[string]struct{}
+
+func (s eventFilterSet) matches(v string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	_, ok := s[v]
+	return ok
+}
+
+func (s eventFilterSet) list() []string {
+	var l []string
+	for k := range s {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+	return l
+}
+
+type eventFilter struct {
+	types      eventFilterSet
+	namespaces eventFilterSet
+	names      eventFilterSet
+}
+
+func (f *eventFilter) matches(evt *imagesv1.ImageEventsResponse) bool {
+	return f.types.matches(evt.Type) && f.namespaces.matches(evt.Namespace) && f.names.matches(evt.Name)
+}
+
+func parseEventFilters(filters []string) (*eventFilter, error) {
+	f := &eventFilter{
+		types:      eventFilterSet{},
+		namespaces: eventFilterSet{},
+		names:      eventFilterSet{},
+	}
+	for _, filter := range filters {
+		kv := strings.SplitN(filter, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, errors.Errorf("invalid filter %q, expected key=value", filter)
+		}
+		switch k, v := strings.ToLower(kv[0]), kv[1]; k {
+		case "type":
+			switch v {
+			case EventTypeImage, EventTypeContent, EventTypeBuild:
+				f.types[v] = struct{}{}
+			default:
+				return nil, errors.Errorf("invalid event type %q, expected one of %s, %s or %s", v, EventTypeImage, EventTypeContent, EventTypeBuild)
+			}
+		case "namespace":
+			f.namespaces[v] = struct{}{}
+		case "name", "image":
+			f.names[v] = struct{}{}
+		default:
+			return nil, errors.Errorf("invalid filter key %q, expected one of type, namespace or name", k)
+		}
+	}
+	return f, nil
+}
@@ -2,76 +2,153 @@ package server
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/platforms"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/progress"
 	"github.com/sirupsen/logrus"
-	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
-// Pull server-side impl
-func (i *Interface) Pull(ctx context.Context, request *imagesv1.ImagePullRequest) (*imagesv1.ImagePullResponse, error) {
-	req := &criv1.PullImageRequest{
-		Image: request.Image,
+// Pull server-side impl, pulls via the containerd client (rather than CRI) so that only the content of the requested
+// image is tracked and so that platforms other than that of the host can be pulled. Content is only unpacked when the
+// requested platform can be run by the builder.
+// Progress is streamed to the client until the final response naming the pulled image.
+func (i *Interface) Pull(request *imagesv1.ImagePullRequest, srv imagesv1.Images_PullServer) error {
+	ref, err := requestImage(request.GetImage())
+	if err != nil {
+		return err
 	}
-	res, err := i.ImageService.PullImage(ctx, req)
+	ctx := namespaces.WithNamespace(srv.Context(), i.imageNamespace())
+	trackerCtx, cancel := context.WithCancel(ctx)
+	tracker := progress.NewPullTracker(trackerCtx, progress.NewContentStatusTracker(ctx, i.Containerd.ContentStore()))
+	var res *imagesv1.ImagePullResponse
+	err = sendProgress(tracker, cancel, func(status []imagesv1.ImageStatus) error {
+		return srv.Send(&imagesv1.ImagePullResponse{Status: status})
+	}, func() (err error) {
+		start := time.Now()
+		res, err = i.pull(ctx, request, tracker)
+		recordOperation("pull", start, err)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		logrus.Debugf("image-pull-error: %s -> %v", ref, err)
+		return err
 	}
-	return &imagesv1.ImagePullResponse{
-		Image: res.ImageRef,
-	}, nil
+	return srv.Send(res)
 }
 
-// PullProgress server-side impl
-func (i *Interface) PullProgress(req *imagesv1.ImageProgressRequest, srv imagesv1.Images_PullProgressServer) error {
-	ctx := namespaces.WithNamespace(srv.Context(), "k8s.io")
+func (i *Interface) pull(ctx context.Context, request *imagesv1.ImagePullRequest, tracker progress.Tracker) (*imagesv1.ImagePullResponse, error) {
+	named, err := refdocker.ParseDockerRef(request.GetImage().GetImage())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid reference %q", request.GetImage().GetImage())
+	}
+	ref := named.String()
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(100 * time.Millisecond):
-			isr, err := i.ImageService.ImageStatus(ctx, &criv1.ImageStatusRequest{
-				Image: &criv1.ImageSpec{
-					Image: req.Image,
-				},
-			})
+	unpack := !request.AllPlatforms
+	matcher := platforms.All
+	if !request.AllPlatforms {
+		platform := platforms.DefaultSpec()
+		if request.Platform != "" {
+			platform, err = platforms.Parse(request.Platform)
 			if err != nil {
-				logrus.Debugf("pull-progress-image-status-error: %v", err)
-				return err
-			}
-			if isr.Image != nil {
-				logrus.Debugf("pull-progress-image-status-done: %s", isr.Image)
-				return nil
-			}
-			csl, err := i.Containerd.ContentStore().ListStatuses(ctx, "") // TODO is this filter too broad?
-			if err != nil {
-				logrus.Debugf("pull-progress-content-status-error: %v", err)
-				return err
-			}
-			res := &imagesv1.ImageProgressResponse{}
-			for _, s := range csl {
-				status := "waiting"
-				if s.Offset == s.Total {
-					status = "unpacking"
-				} else if s.Offset > 0 {
-					status = "downloading"
-				}
-				res.Status = append(res.Status, imagesv1.ImageStatus{
-					Status:    status,
-					Ref:       s.Ref,
-					Offset:    s.Offset,
-					Total:     s.Total,
-					StartedAt: s.StartedAt,
-					UpdatedAt: s.UpdatedAt,
-				})
-			}
-			if err = srv.Send(res); err != nil {
-				logrus.Debugf("pull-progress-content-send-error: %v", err)
-				return err
+				return nil, errors.Wrapf(err, "invalid platform %q", request.Platform)
 			}
+			unpack = platforms.Default().Match(platform)
+		}
+		matcher = platforms.Only(platform)
+	}
+
+	// the imagePullSecrets of the service account take precedence over the credentials of the client
+	auths, err := i.serviceAccountAuth(ctx, ref, request.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := i.resolver("pull", append(auths, request.Auth...), nil)
+	if err != nil {
+		return nil, err
+	}
+	tracker.Add(ref)
+	tracker.Update(ref, "resolving")
+
+	var (
+		layers []string
+		mu     sync.Mutex
+	)
+	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		key := remotes.MakeRefKey(ctx, desc)
+		tracker.Update(ref, "resolved")
+		tracker.Add(key)
+		if images.IsLayerType(desc.MediaType) {
+			mu.Lock()
+			layers = append(layers, key)
+			mu.Unlock()
+		}
+		return nil, nil
+	})
+	img, err := i.Containerd.Pull(ctx, ref,
+		containerd.WithResolver(resolver),
+		containerd.WithPlatformMatcher(matcher),
+		containerd.WithImageHandler(handler),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if unpack {
+		for _, layer := range layers {
+			tracker.Update(layer, "extracting")
+		}
+		if err = img.Unpack(ctx, i.snapshotter()); err != nil {
+			return nil, errors.Wrapf(err, "failed to unpack image on snapshotter %s", i.snapshotter())
+		}
+		for _, layer := range layers {
+			tracker.Update(layer, "")
+		}
+	}
+
+	// mirror the references that the CRI creates when pulling
+	refs := []string{named.Name() + "@" + img.Target().Digest.String()}
+	if !request.AllPlatforms {
+		config, err := img.Config(ctx)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, config.Digest.String())
+	}
+	for _, name := range refs {
+		if err = i.createImageReference(ctx, name, img.Target()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &imagesv1.ImagePullResponse{
+		Image: img.Name(),
+	}, nil
+}
+
+// createImageReference creates or updates the image record named for the target
+func (i *Interface) createImageReference(ctx context.Context, name string, target ocispec.Descriptor) error {
+	svc := i.Containerd.ImageService()
+	img := images.Image{
+		Name:   name,
+		Target: target,
+	}
+	if _, err := svc.Create(ctx, img); err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return err
+		}
+		if _, err = svc.Update(ctx, img, "target"); err != nil {
+			return err
 		}
 	}
+	return nil
 }
@@ -2,90 +2,134 @@ package server
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/containerd/containerd"
-	"github.com/containerd/containerd/cmd/ctr/commands"
+	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
-	"github.com/rancher/k3c/pkg/auth"
+	"github.com/rancher/k3c/pkg/converter"
 	"github.com/rancher/k3c/pkg/progress"
-	"github.com/rancher/k3c/pkg/version"
 	"github.com/sirupsen/logrus"
 )
 
-// Push server-side impl
-func (i *Interface) Push(ctx context.Context, request *imagesv1.ImagePushRequest) (*imagesv1.ImagePushResponse, error) {
-	ctx = namespaces.WithNamespace(ctx, "k8s.io")
-	img, err := i.Containerd.ImageService().Get(ctx, request.Image.Image)
+// Push server-side impl, pushes the image to each of the targets in turn (defaulting to the image itself), converting
+// the layers to the requested compression first. Blobs are labeled with the repositories they were pushed to so that
+// pushes to other repositories of the same registry mount them rather than uploading them again.
+// Progress is streamed to the client until the final response listing the pushed targets.
+func (i *Interface) Push(request *imagesv1.ImagePushRequest, srv imagesv1.Images_PushServer) error {
+	ref, err := requestImage(request.GetImage())
+	if err != nil {
+		return err
+	}
+	ctx := namespaces.WithNamespace(srv.Context(), i.imageNamespace())
+	trackerCtx, cancel := context.WithCancel(ctx)
+	// each push tracks its own uploads, concurrent pushes of the same blobs would otherwise report each other's progress
+	statusTracker := docker.NewInMemoryTracker()
+	tracker := progress.NewTracker(trackerCtx, statusTracker)
+	var res *imagesv1.ImagePushResponse
+	err = sendProgress(tracker, cancel, func(status []imagesv1.ImageStatus) error {
+		return srv.Send(&imagesv1.ImagePushResponse{Status: status})
+	}, func() (err error) {
+		start := time.Now()
+		res, err = i.push(ctx, request, tracker, statusTracker)
+		recordOperation("push", start, err)
+		return err
+	})
+	if err != nil {
+		logrus.Debugf("image-push-error: %s -> %v", ref, err)
+		return err
+	}
+	return srv.Send(res)
+}
+
+func (i *Interface) push(ctx context.Context, request *imagesv1.ImagePushRequest, tracker progress.Tracker, statusTracker docker.StatusTracker) (*imagesv1.ImagePushResponse, error) {
+	img, err := i.lookupImage(ctx, request.GetImage().GetImage())
+	if err != nil {
+		return nil, err
+	}
+	targets := request.Targets
+	if len(targets) == 0 {
+		if imageIDPattern.MatchString(img.Name) {
+			return nil, errors.Errorf("image %q has no name to push to, specify the targets", request.GetImage().GetImage())
+		}
+		targets = []string{img.Name}
+	}
+	for j, target := range targets {
+		if targets[j], err = normalizeReference(target); err != nil {
+			return nil, err
+		}
+	}
+
+	resolver, err := i.resolver("push", request.Auth, statusTracker)
 	if err != nil {
 		return nil, err
 	}
 
-	authorizer := docker.NewDockerAuthorizer(
-		docker.WithAuthClient(http.DefaultClient),
-		docker.WithAuthCreds(func(host string) (string, string, error) {
-			return auth.Parse(request.Auth, host)
-		}),
-		docker.WithAuthHeader(http.Header{
-			"User-Agent": []string{fmt.Sprintf("k3c/%s", version.Version)},
-		}),
-	)
-	resolver := docker.NewResolver(docker.ResolverOptions{
-		Tracker: commands.PushTracker,
-		Hosts: docker.ConfigureDefaultRegistries(
-			docker.WithAuthorizer(authorizer),
-		),
-	})
-	tracker := progress.NewTracker(ctx, commands.PushTracker)
-	i.pushes.Store(img.Name, tracker)
+	desc := img.Target
+	if request.Compression != "" {
+		// the converted content only needs to outlive the push
+		var done func(context.Context) error
+		ctx, done, err = i.Containerd.WithLease(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer done(ctx)
+		tracker.Add(img.Name)
+		tracker.Update(img.Name, "converting")
+		desc, err = converter.Convert(ctx, i.Containerd.ContentStore(), desc, converter.Options{
+			Compression: request.Compression,
+			Force:       request.ForceCompression,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tracker.Update(img.Name, "converted")
+	}
 	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		tracker.Add(remotes.MakeRefKey(ctx, desc))
 		return nil, nil
 	})
-	err = i.Containerd.Push(ctx, img.Name, img.Target,
-		containerd.WithResolver(resolver),
-		containerd.WithImageHandler(handler),
-	)
-	if err != nil {
-		return nil, err
+	for _, target := range targets {
+		err = i.Containerd.Push(ctx, target, desc,
+			containerd.WithResolver(resolver),
+			containerd.WithImageHandler(handler),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to push %s", target)
+		}
+		if err = i.appendDistributionSource(ctx, target, desc); err != nil {
+			logrus.Warnf("push: failed to label content pushed to %s: %v", target, err)
+		}
 	}
 	return &imagesv1.ImagePushResponse{
-		Image: img.Name,
+		Image:   img.Name,
+		Targets: targets,
 	}, nil
 }
 
-// PushProgress server-side impl
-func (i *Interface) PushProgress(req *imagesv1.ImageProgressRequest, srv imagesv1.Images_PushProgressServer) error {
-	ctx := namespaces.WithNamespace(srv.Context(), "k8s.io")
-	defer i.pushes.Delete(req.Image)
-
-	timeout := time.After(15 * time.Second)
-
-	for {
-		if tracker, tracking := i.pushes.Load(req.Image); tracking {
-			for status := range tracker.(progress.Tracker).Status() {
-				if err := srv.Send(&imagesv1.ImageProgressResponse{Status: status}); err != nil {
-					logrus.Debugf("push-progress-error: %s -> %v", req.Image, err)
-					return err
-				}
+// appendDistributionSource labels the content of the image with the repository it was pushed to, as pulls do,
+// enabling cross-repository mounts.
+func (i *Interface) appendDistributionSource(ctx context.Context, ref string, target ocispec.Descriptor) error {
+	store := i.Containerd.ContentStore()
+	labeler, err := docker.AppendDistributionSourceLabel(store, ref)
+	if err != nil {
+		return err
+	}
+	// content of platforms that were not pulled is skipped
+	skipMissing := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if _, err := labeler(ctx, desc); err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil, images.ErrSkipDesc
 			}
-			logrus.Debugf("push-progress-done: %s", req.Image)
-			return nil
-		}
-		select {
-		case <-timeout:
-			logrus.Debugf("push-progress-timeout: not tracking %s", req.Image)
-			return nil
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(100 * time.Millisecond):
+			return nil, err
 		}
-	}
+		return nil, nil
+	})
+	return images.Walk(ctx, images.Handlers(skipMissing, images.ChildrenHandler(store)), target)
 }
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/sirupsen/logrus"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+// Remove image server-side impl, mirroring `docker rmi`: removing a reference to an image with other tags only
+// untags it, otherwise the image is deleted unless used by containers. Removing by ID deletes the image unless it is
+// tagged in multiple repositories. The copies of the removed references in the buildkit namespace are removed too.
+func (i *Interface) Remove(ctx context.Context, req *imagesv1.ImageRemoveRequest) (*imagesv1.ImageRemoveResponse, error) {
+	ref, err := requestImage(req.GetImage())
+	if err != nil {
+		return nil, err
+	}
+	ctx = namespaces.WithNamespace(ctx, i.imageNamespace())
+	img, err := i.lookupImage(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	image, err := i.imageStatus(ctx, img.Name)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "image %q", ref)
+	}
+
+	res := &imagesv1.ImageRemoveResponse{}
+	if imageIDPattern.MatchString(img.Name) {
+		if repos := imageRepositories(image); len(repos) > 1 && !req.Force {
+			return nil, errors.Errorf("unable to remove %s (must be forced): image is referenced in multiple repositories", ref)
+		}
+	} else if hasOtherTags(image, img.Name) {
+		if err = i.Containerd.ImageService().Delete(ctx, img.Name); err != nil {
+			return nil, err
+		}
+		res.Untagged = []string{img.Name}
+		i.removeBuildkitImages(ctx, res.Untagged)
+		return res, nil
+	}
+
+	if !req.Force {
+		container, err := i.imageContainer(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		if container != "" {
+			return nil, errors.Errorf("unable to remove %s (must be forced): image is being used by container %s", ref, container)
+		}
+	}
+	if err = i.removeImage(ctx, image); err != nil {
+		return nil, err
+	}
+	res.Untagged = append(append([]string{}, image.RepoTags...), image.RepoDigests...)
+	res.Deleted = []string{image.Id}
+	i.removeBuildkitImages(ctx, res.Untagged)
+	return res, nil
+}
+
+// removeBuildkitImages removes the images of the same names that were built in (and copied from) the buildkit namespace
+func (i *Interface) removeBuildkitImages(ctx context.Context, names []string) {
+	ns := i.config.BuildkitNamespace
+	if ns == "" || ns == i.imageNamespace() {
+		return
+	}
+	ctx = namespaces.WithNamespace(ctx, ns)
+	svc := i.Containerd.ImageService()
+	for _, name := range names {
+		if err := svc.Delete(ctx, name); err != nil && !errdefs.IsNotFound(err) {
+			logrus.Warnf("remove: failed to remove %s from namespace %s: %v", name, ns, err)
+		}
+	}
+}
+
+// hasOtherTags returns whether the image is tagged other than by the named reference
+func hasOtherTags(image *criv1.Image, name string) bool {
+	for _, tag := range image.RepoTags {
+		if tag != name {
+			return true
+		}
+	}
+	return false
+}
+
+// imageRepositories returns the distinct repositories the image is tagged in
+func imageRepositories(image *criv1.Image) map[string]struct{} {
+	repos := map[string]struct{}{}
+	for _, tag := range image.RepoTags {
+		if named, err := refdocker.ParseNormalizedNamed(tag); err == nil {
+			repos[named.Name()] = struct{}{}
+		}
+	}
+	return repos
+}
@@ -4,48 +4,90 @@ import (
 	"context"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/pkg/errors"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/sirupsen/logrus"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
-// Tag image server-side impl, adapted from containerd's `ctr tag` implementation
+// Tag image server-side impl, adapted from containerd's `ctr tag` implementation. Tagging into another namespace
+// copies the content of the image there.
 func (i *Interface) Tag(ctx context.Context, req *imagesv1.ImageTagRequest) (*imagesv1.ImageTagResponse, error) {
-	// containerd services require a namespace
-	ctx, done, err := i.Containerd.WithLease(namespaces.WithNamespace(ctx, "k8s.io"))
+	ref, err := requestImage(req.GetImage())
 	if err != nil {
 		return nil, err
 	}
-	defer done(ctx)
-	ref := req.Image.Image // TODO normalize this
-	svc := i.Containerd.ImageService()
-	img, err := svc.Get(ctx, ref)
+	if len(req.Tags) == 0 {
+		return nil, errors.New("no tags to create")
+	}
+	from, to, err := i.copyNamespaces(req.FromNamespace, req.ToNamespace)
+	if err != nil {
+		return nil, err
+	}
+	img, err := i.lookupImage(namespaces.WithNamespace(ctx, from), ref)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, len(req.Tags))
+	for j, tag := range req.Tags {
+		if tags[j], err = normalizeTag(tag); err != nil {
+			return nil, err
+		}
+	}
+	img = taggedImage(img, from)
+	if from == to {
+		err = i.tagImage(namespaces.WithNamespace(ctx, to), img, tags)
+	} else {
+		for _, tag := range tags {
+			if err = i.copyImage(ctx, from, to, img, tag); err != nil {
+				break
+			}
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
-	for _, tag := range req.Tags {
+	if to != i.imageNamespace() {
+		// only images in the image namespace have a status
+		return &imagesv1.ImageTagResponse{
+			Image: &criv1.Image{RepoTags: tags},
+		}, nil
+	}
+	image, err := i.imageStatus(ctx, tags[len(tags)-1])
+	if err != nil {
+		return nil, err
+	}
+	return &imagesv1.ImageTagResponse{
+		Image: image,
+	}, nil
+}
+
+// tagImage creates the tags for the image in the namespace of the context, replacing any existing images so named
+func (i *Interface) tagImage(ctx context.Context, img images.Image, tags []string) error {
+	ctx, done, err := i.Containerd.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done(ctx)
+	svc := i.Containerd.ImageService()
+	for _, tag := range tags {
 		img.Name = tag
 		// Attempt to create the image first
 		if _, err = svc.Create(ctx, img); err != nil {
 			if errdefs.IsAlreadyExists(err) {
 				if err = svc.Delete(ctx, tag); err != nil {
-					return nil, err
+					return err
 				}
 				if _, err = svc.Create(ctx, img); err != nil {
-					return nil, err
+					return err
 				}
 			} else {
-				return nil, err
+				return err
 			}
 		}
 		logrus.Debugf("%#v", img)
 	}
-	res, err := i.ImageService.ImageStatus(ctx, &criv1.ImageStatusRequest{Image: req.Image})
-	if err != nil {
-		return nil, err
-	}
-	return &imagesv1.ImageTagResponse{
-		Image: res.Image,
-	}, nil
+	return nil
 }
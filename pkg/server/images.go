@@ -2,38 +2,109 @@ package server
 
 import (
 	"context"
+	"sort"
+	"strings"
 
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
-// List images server-side impl
+// distributionSourceLabel prefixes the labels of content naming the repositories of a registry it is sourced from
+const distributionSourceLabel = "containerd.io/distribution.source."
+
+// List images server-side impl, the cri does not implement filters so the image of the filter, if any, is matched here
 func (i *Interface) List(ctx context.Context, req *imagesv1.ImageListRequest) (*imagesv1.ImageListResponse, error) {
-	res, err := i.ImageService.ListImages(ctx, &criv1.ListImagesRequest{Filter: req.Filter})
+	list, err := i.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ref := req.GetFilter().GetImage().GetImage()
+	if ref == "" {
+		return &imagesv1.ImageListResponse{
+			Images: list,
+		}, nil
+	}
+	matches, err := imageFilter(ref)
 	if err != nil {
 		return nil, err
 	}
+	var filtered []*criv1.Image
+	for _, image := range list {
+		if matches(image) {
+			filtered = append(filtered, image)
+		}
+	}
 	return &imagesv1.ImageListResponse{
-		Images: res.Images,
+		Images: filtered,
 	}, nil
 }
 
-// Status of an image server-side impl (unused)
+// Status of an image server-side impl, along with the repositories its content is sourced from
 func (i *Interface) Status(ctx context.Context, req *imagesv1.ImageStatusRequest) (*imagesv1.ImageStatusResponse, error) {
-	res, err := i.ImageService.ImageStatus(ctx, &criv1.ImageStatusRequest{Image: req.Image})
+	ref, err := requestImage(req.GetImage())
+	if err != nil {
+		return nil, err
+	}
+	img, err := i.lookupImage(namespaces.WithNamespace(ctx, i.imageNamespace()), ref)
+	if errdefs.IsNotFound(err) {
+		// as with the cri, a missing image is not an error
+		return &imagesv1.ImageStatusResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	image, err := i.imageStatus(ctx, img.Name)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := i.distributionSources(namespaces.WithNamespace(ctx, i.imageNamespace()), img)
 	if err != nil {
 		return nil, err
 	}
 	return &imagesv1.ImageStatusResponse{
-		Image: res.Image,
+		Image:   image,
+		Sources: sources,
 	}, nil
 }
 
-// Remove image server-side impl
-func (i *Interface) Remove(ctx context.Context, req *imagesv1.ImageRemoveRequest) (*imagesv1.ImageRemoveResponse, error) {
-	_, err := i.ImageService.RemoveImage(ctx, &criv1.RemoveImageRequest{Image: req.Image})
-	if err != nil {
+// distributionSources returns the repositories, as registry/repository, that the content of the image was pulled from
+// or pushed to, as labeled by containerd. Pushes may mount the blobs of the image from these repositories, requiring
+// credentials for their registries too. Content missing from the store, e.g. of platforms not pulled, is skipped.
+func (i *Interface) distributionSources(ctx context.Context, img images.Image) ([]string, error) {
+	store := i.Containerd.ContentStore()
+	var (
+		sources []string
+		seen    = map[string]bool{}
+	)
+	handler := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		info, err := store.Info(ctx, desc.Digest)
+		if errdefs.IsNotFound(err) {
+			return nil, images.ErrSkipDesc
+		}
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range info.Labels {
+			if !strings.HasPrefix(key, distributionSourceLabel) {
+				continue
+			}
+			registry := strings.TrimPrefix(key, distributionSourceLabel)
+			for _, repository := range strings.Split(value, ",") {
+				if source := registry + "/" + repository; !seen[source] {
+					seen[source] = true
+					sources = append(sources, source)
+				}
+			}
+		}
+		return nil, nil
+	})
+	if err := images.Walk(ctx, images.Handlers(handler, images.ChildrenHandler(store)), img.Target); err != nil {
 		return nil, err
 	}
-	return &imagesv1.ImageRemoveResponse{}, nil
+	sort.Strings(sources)
+	return sources, nil
 }
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+func TestRequestsWithoutImage(t *testing.T) {
+	i := &Interface{}
+	ctx := context.Background()
+	for _, spec := range []*criv1.ImageSpec{nil, {}} {
+		tests := map[string]func() error{
+			"pull": func() error {
+				return i.Pull(&imagesv1.ImagePullRequest{Image: spec}, nil)
+			},
+			"push": func() error {
+				return i.Push(&imagesv1.ImagePushRequest{Image: spec}, nil)
+			},
+			"status": func() error {
+				_, err := i.Status(ctx, &imagesv1.ImageStatusRequest{Image: spec})
+				return err
+			},
+			"remove": func() error {
+				_, err := i.Remove(ctx, &imagesv1.ImageRemoveRequest{Image: spec})
+				return err
+			},
+			"copy": func() error {
+				_, err := i.Copy(ctx, &imagesv1.ImageCopyRequest{Image: spec})
+				return err
+			},
+			"convert": func() error {
+				_, err := i.Convert(ctx, &imagesv1.ImageConvertRequest{Image: spec})
+				return err
+			},
+			"tag": func() error {
+				_, err := i.Tag(ctx, &imagesv1.ImageTagRequest{Image: spec, Tags: []string{"app:latest"}})
+				return err
+			},
+		}
+		for name, call := range tests {
+			t.Run(name, func(t *testing.T) {
+				if err := call(); status.Code(err) != codes.InvalidArgument {
+					t.Errorf("%s of image %v returned %v, expected an invalid argument", name, spec, err)
+				}
+			})
+		}
+	}
+}
+
+func TestImageEventBuilds(t *testing.T) {
+	i := &Interface{config: &Config{BuildkitNamespace: "buildkit"}}
+	tests := []struct {
+		name   string
+		ns     string
+		action string
+		labels map[string]string
+		build  bool
+	}{
+		{name: "exported by a build", ns: "buildkit", action: EventActionCreate, build: true},
+		{name: "exported again", ns: "buildkit", action: EventActionUpdate, build: true},
+		{name: "deleted", ns: "buildkit", action: EventActionDelete},
+		{name: "tagged", ns: "buildkit", action: EventActionCreate, labels: map[string]string{TaggedFromLabel: "buildkit"}},
+		{name: "copied", ns: "buildkit", action: EventActionCreate, labels: map[string]string{TaggedFromLabel: "k8s.io"}},
+		{name: "synced", ns: "buildkit", action: EventActionCreate, labels: map[string]string{SyncedFromLabel: "moby"}},
+		{name: "other namespace", ns: "k8s.io", action: EventActionCreate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var build bool
+			for _, evt := range i.imageEvent(tt.ns, tt.action, "docker.io/library/app:latest", tt.labels) {
+				build = build || evt.Type == EventTypeBuild
+			}
+			if build != tt.build {
+				t.Errorf("%s of an image labeled %v in %s is a build: %t, expected %t", tt.action, tt.labels, tt.ns, build, tt.build)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"os"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/platforms"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/version"
+	"github.com/sirupsen/logrus"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+// Info server-side impl, the details of unavailable backends are omitted (and logged) rather than failing the call so
+// that the info helps diagnose them.
+func (i *Interface) Info(ctx context.Context, _ *imagesv1.InfoRequest) (*imagesv1.InfoResponse, error) {
+	res := &imagesv1.InfoResponse{
+		Version:   version.Version,
+		GitCommit: version.GitCommit,
+		Node:      nodeName(),
+	}
+	var err error
+	if res.Buildkit, err = i.buildkitInfo(ctx); err != nil {
+		logrus.Warnf("info: buildkit: %v", err)
+	}
+	if res.Containerd, err = i.containerdInfo(ctx); err != nil {
+		logrus.Warnf("info: containerd: %v", err)
+	}
+	if i.usesCRI() {
+		if res.Runtime, err = i.runtimeInfo(ctx); err != nil {
+			logrus.Warnf("info: cri: %v", err)
+		}
+	}
+	if res.Images, err = i.imagesInfo(ctx, res.Containerd.GetNamespaces()); err != nil {
+		logrus.Warnf("info: images: %v", err)
+	}
+	res.Health = map[string]string{}
+	for service, err := range i.checkHealth(ctx) {
+		res.Health[service] = healthv1.HealthCheckResponse_SERVING.String()
+		if err != nil {
+			res.Health[service] = err.Error()
+		}
+	}
+	return res, nil
+}
+
+func (i *Interface) buildkitInfo(ctx context.Context) (*imagesv1.BuildkitInfo, error) {
+	workers, err := i.Buildkit.ListWorkers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &imagesv1.BuildkitInfo{}
+	for _, w := range workers {
+		worker := &imagesv1.BuildkitWorker{
+			Id:     w.ID,
+			Labels: w.Labels,
+		}
+		for _, p := range w.Platforms {
+			worker.Platforms = append(worker.Platforms, platforms.Format(p))
+		}
+		info.Workers = append(info.Workers, worker)
+	}
+	if info.Version, info.Image, err = i.buildkitImage(); err != nil {
+		logrus.Warnf("info: buildkit version: %v", err)
+	}
+	return info, nil
+}
+
+// buildkitImage returns the version and image of the buildkitd container of the pod of the agent, as set by the
+// installer. Buildkit (as of v0.8) does not report its version over its api, the tag of its image is thus the version.
+func (i *Interface) buildkitImage() (string, string, error) {
+	name := os.Getenv("POD_NAME")
+	if name == "" || i.Kubernetes == nil {
+		return "", "", errors.New("the pod of the agent is unknown")
+	}
+	pod, err := i.Kubernetes.Core.Pod().Get(i.Kubernetes.Namespace, name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != "buildkit" {
+			continue
+		}
+		var version string
+		if named, err := refdocker.ParseDockerRef(status.Image); err == nil {
+			if tagged, ok := named.(refdocker.Tagged); ok {
+				version = tagged.Tag()
+			}
+		}
+		image := status.ImageID
+		if image == "" {
+			image = status.Image
+		}
+		return version, image, nil
+	}
+	return "", "", errors.Errorf("pod %s has no buildkit container", name)
+}
+
+func (i *Interface) containerdInfo(ctx context.Context) (*imagesv1.ContainerdInfo, error) {
+	v, err := i.Containerd.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nss, err := i.Containerd.NamespaceService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &imagesv1.ContainerdInfo{
+		Version:    v.Version,
+		Revision:   v.Revision,
+		Namespaces: nss,
+	}, nil
+}
+
+func (i *Interface) runtimeInfo(ctx context.Context) (*imagesv1.RuntimeInfo, error) {
+	v, err := i.RuntimeService.Version(ctx, &criv1.VersionRequest{})
+	if err != nil {
+		return nil, err
+	}
+	status, err := i.RuntimeService.Status(ctx, &criv1.StatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &imagesv1.RuntimeInfo{
+		Name:       v.RuntimeName,
+		Version:    v.RuntimeVersion,
+		ApiVersion: v.RuntimeApiVersion,
+		Conditions: status.GetStatus().GetConditions(),
+	}, nil
+}
+
+func (i *Interface) imagesInfo(ctx context.Context, contentNamespaces []string) (*imagesv1.ImagesInfo, error) {
+	list, err := i.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := &imagesv1.ImagesInfo{
+		Namespace:    i.imageNamespace(),
+		Count:        int64(len(list)),
+		ContentSizes: map[string]int64{},
+	}
+	for _, image := range list {
+		info.Size_ += image.Size_
+	}
+	// the usage of the filesystems is only reported by the cri
+	if i.usesCRI() {
+		fs, err := i.ImageService.ImageFsInfo(ctx, &criv1.ImageFsInfoRequest{})
+		if err != nil {
+			return nil, err
+		}
+		info.Filesystems = fs.ImageFilesystems
+	}
+	for _, ns := range contentNamespaces {
+		size, err := i.contentSize(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		info.ContentSizes[ns] = size
+	}
+	return info, nil
+}
+
+// contentSize returns the size of the content stored in the namespace
+func (i *Interface) contentSize(ctx context.Context, ns string) (int64, error) {
+	var size int64
+	err := i.Containerd.ContentStore().Walk(namespaces.WithNamespace(ctx, ns), func(info content.Info) error {
+		size += info.Size
+		return nil
+	})
+	return size, err
+}
+
+// nodeName returns the name of the node that the agent runs on, as set by the installer, or the hostname
+func nodeName() string {
+	if name := os.Getenv("NODE_NAME"); name != "" {
+		return name
+	}
+	name, _ := os.Hostname()
+	return name
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/pkg/errors"
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/auth"
+	"github.com/rancher/k3c/pkg/registries"
+	"github.com/rancher/k3c/pkg/version"
+)
+
+// Login server-side impl, verifies the credentials against the registry that images are pushed to, i.e. as resolved
+// by the agent, honoring the mirrors and TLS of the registries configuration but not its auth: only the credentials of
+// the request are verified.
+func (i *Interface) Login(ctx context.Context, req *imagesv1.LoginRequest) (*imagesv1.LoginResponse, error) {
+	registry, err := registries.Load(i.config.RegistriesFile)
+	if err != nil {
+		return nil, err
+	}
+	credentials := func(host string) (string, string, error) {
+		return auth.Parse(req.Auth, host)
+	}
+	hosts, err := registry.Hosts(registries.HostOptions{
+		Header: http.Header{
+			"User-Agent": []string{fmt.Sprintf("k3c/%s", version.Version)},
+		},
+		Transport: i.transport("login"),
+	})(req.Server)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		if host.Capabilities.Has(docker.HostCapabilityPush) {
+			return &imagesv1.LoginResponse{}, registries.Login(ctx, host, credentials)
+		}
+	}
+	return nil, errors.Errorf("no registry host for %s", req.Server)
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	registrySentBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k3c_registry_sent_bytes_total",
+		Help: "Bytes sent to registries, by operation (pull or push).",
+	}, []string{"operation"})
+	registryReceivedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k3c_registry_received_bytes_total",
+		Help: "Bytes received from registries, by operation (pull or push).",
+	}, []string{"operation"})
+	operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k3c_operation_duration_seconds",
+		Help:    "Duration of image pulls and pushes, by operation and result.",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"operation", "result"})
+	imageSyncs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "k3c_image_syncs_total",
+		Help: "Images synced between containerd namespaces, by result.",
+	}, []string{"result"})
+	imageSyncDrift = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k3c_image_sync_drift",
+		Help: "Images missing, stale or orphaned in a namespace as of the last reconciliation with the namespace synced from.",
+	}, []string{"from", "to", "reason"})
+	builds = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "k3c_builds_total",
+		Help: "Images built, i.e. exported to the buildkit namespace with content written since the previous build.",
+	})
+	buildDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "k3c_build_duration_seconds",
+		Help:    "Duration of builds, from the first content written for the image to its export.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	})
+	contentBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k3c_content_bytes",
+		Help: "Size of the content stored by containerd, by namespace, as of the last reconciliation.",
+	}, []string{"namespace"})
+)
+
+func init() {
+	prometheus.MustRegister(registrySentBytes, registryReceivedBytes, operationDuration, imageSyncs, imageSyncDrift,
+		builds, buildDuration, contentBytes)
+}
+
+// RecordBuild counts the image exported to the buildkit namespace as a build, unless tagged, copied or synced there by
+// k3c or its target was written before the previous build, e.g. when tagged otherwise. Builds are submitted to buildkit directly, rather than via the agent, so their
+// duration is that of the content written for the image since the previous build, concurrent builds being cut short.
+func (i *Interface) RecordBuild(ctx context.Context, name string) error {
+	now := time.Now()
+	img, err := i.Containerd.ImageService().Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !builtImage(img.Labels) {
+		return nil
+	}
+	store := i.Containerd.ContentStore()
+	target, err := store.Info(ctx, img.Target.Digest)
+	if err != nil {
+		return err
+	}
+	i.buildsMu.Lock()
+	since := i.lastBuild
+	if target.CreatedAt.After(since) {
+		i.lastBuild = now
+	}
+	i.buildsMu.Unlock()
+	if !target.CreatedAt.After(since) {
+		return nil
+	}
+	start := target.CreatedAt
+	earliest := images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		info, err := store.Info(ctx, desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if info.CreatedAt.After(since) && info.CreatedAt.Before(start) {
+			start = info.CreatedAt
+		}
+		return nil, nil
+	})
+	if err := images.Walk(ctx, images.Handlers(earliest, pulledChildrenHandler(store)), img.Target); err != nil {
+		return err
+	}
+	builds.Inc()
+	buildDuration.Observe(now.Sub(start).Seconds())
+	return nil
+}
+
+// RecordImageSync counts the result of syncing an image from the buildkit namespace
+func RecordImageSync(err error) {
+	imageSyncs.WithLabelValues(result(err)).Inc()
+}
+
+// recordOperation observes the duration of the operation started at the passed time
+func recordOperation(operation string, start time.Time, err error) {
+	operationDuration.WithLabelValues(operation, result(err)).Observe(time.Since(start).Seconds())
+}
+
+// RecordContentSize sets the gauges of the size of the content stored in each of the namespaces, which are sized
+// along with the reconciliation rather than when collected as walking the content store is costly.
+func (i *Interface) RecordContentSize(ctx context.Context, namespaceNames ...string) {
+	for _, ns := range namespaceNames {
+		size, err := i.contentSize(ctx, ns)
+		if err != nil {
+			logrus.Warnf("metrics: failed to size content of namespace %s: %v", ns, err)
+			continue
+		}
+		contentBytes.WithLabelValues(ns).Set(float64(size))
+	}
+}
+
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
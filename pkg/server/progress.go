@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/progress"
+)
+
+// sendProgress runs the operation, sending the status reported by the tracker until the operation completes. The
+// tracker must have been created with a context canceled by the passed cancel func so that it reports its final
+// status and stops.
+func sendProgress(tracker progress.Tracker, cancel context.CancelFunc, send func([]imagesv1.ImageStatus) error, operation func() error) error {
+	result := make(chan error, 1)
+	go func() {
+		defer cancel()
+		result <- operation()
+	}()
+	var sendErr error
+	for status := range tracker.Status() {
+		if sendErr == nil {
+			// failing to send cancels the operation along with the stream context
+			sendErr = send(status)
+		}
+	}
+	if err := <-result; err != nil {
+		return err
+	}
+	return sendErr
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SyncedFromLabel labels the copies of images synced from another namespace with the namespace
+	SyncedFromLabel = "k3c.cattle.io/synced-from"
+	// TaggedFromLabel labels the images tagged or copied by k3c with the namespace of their source, telling those of the
+	// buildkit namespace apart from the images exported by builds
+	TaggedFromLabel = "k3c.cattle.io/tagged-from"
+
+	// SyncDeleteKeep keeps the copies of images deleted from the namespace they were synced from
+	SyncDeleteKeep = "keep"
+	// SyncDeleteUnused deletes the copies of images deleted from the namespace they were synced from, unless used by
+	// containers
+	SyncDeleteUnused = "delete-unused"
+	// SyncDelete deletes the copies of images deleted from the namespace they were synced from
+	SyncDelete = "delete"
+)
+
+// ValidSyncDeletePolicy returns whether the policy for the copies of deleted images is known
+func ValidSyncDeletePolicy(policy string) bool {
+	switch policy {
+	case SyncDeleteKeep, SyncDeleteUnused, SyncDelete:
+		return true
+	}
+	return false
+}
+
+// ReconcileImages copies the images of the source namespace of the rule, that it matches, which are missing from the
+// target namespace, or stale there, i.e. of another target and last updated before the image in the source namespace,
+// and deletes the copies of images that are gone from the source namespace as per the policy of the rule. It catches up
+// with the images that were built (or deleted) while the agent was not watching the events of the source namespace,
+// returning the number of images copied.
+func (i *Interface) ReconcileImages(ctx context.Context, rule *SyncRule, to string) (int, error) {
+	from, deletePolicy := rule.From, rule.DeletePolicy
+	svc := i.Containerd.ImageService()
+	sources, err := svc.List(namespaces.WithNamespace(ctx, from))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list images of namespace %s", from)
+	}
+	targets, err := svc.List(namespaces.WithNamespace(ctx, to))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list images of namespace %s", to)
+	}
+	existing := map[string]images.Image{}
+	for _, img := range targets {
+		existing[img.Name] = img
+	}
+
+	var missing, stale []images.Image
+	for _, img := range sources {
+		target, ok := existing[img.Name]
+		delete(existing, img.Name)
+		// images synced from the target namespace are not synced back
+		if !rule.Matches(img.Name, img.Labels) || img.Labels[SyncedFromLabel] == to {
+			continue
+		}
+		switch {
+		case !ok:
+			missing = append(missing, img)
+		case target.Target.Digest != img.Target.Digest && img.UpdatedAt.After(target.UpdatedAt):
+			stale = append(stale, img)
+		}
+	}
+	var orphaned []string
+	if deletePolicy != SyncDeleteKeep {
+		for name, img := range existing {
+			if img.Labels[SyncedFromLabel] == from && rule.MatchesName(name) {
+				orphaned = append(orphaned, name)
+			}
+		}
+	}
+	imageSyncDrift.WithLabelValues(from, to, "missing").Set(float64(len(missing)))
+	imageSyncDrift.WithLabelValues(from, to, "stale").Set(float64(len(stale)))
+	imageSyncDrift.WithLabelValues(from, to, "orphaned").Set(float64(len(orphaned)))
+	if len(missing) == 0 && len(stale) == 0 && len(orphaned) == 0 {
+		logrus.Debugf("reconcile: images of namespace %s are in sync with %s", to, from)
+		return 0, nil
+	}
+	logrus.Infof("reconcile: %d images missing, %d stale and %d orphaned in namespace %s, syncing from %s",
+		len(missing), len(stale), len(orphaned), to, from)
+
+	var copied, failed int
+	for _, img := range append(missing, stale...) {
+		err := i.copyImage(ctx, from, to, syncedImage(img, from), img.Name)
+		RecordImageSync(err)
+		if err != nil {
+			logrus.Errorf("reconcile: failed to copy %s from namespace %s to %s: %v", img.Name, from, to, err)
+			failed++
+			continue
+		}
+		copied++
+	}
+	for _, name := range orphaned {
+		if err := i.DeleteImageCopy(ctx, from, to, name, deletePolicy); err != nil {
+			logrus.Errorf("reconcile: failed to delete %s from namespace %s: %v", name, to, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return copied, errors.Errorf("failed to sync %d of %d images from namespace %s to %s", failed, failed+copied+len(orphaned), from, to)
+	}
+	return copied, nil
+}
+
+// DeleteImageCopy deletes the copy of an image deleted from the namespace it was synced from, as per the policy.
+// Images that were not synced from the namespace, e.g. pulled since, are kept.
+func (i *Interface) DeleteImageCopy(ctx context.Context, from, to, name, policy string) error {
+	if policy == SyncDeleteKeep {
+		return nil
+	}
+	ctx = namespaces.WithNamespace(ctx, to)
+	img, err := i.Containerd.ImageService().Get(ctx, name)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if img.Labels[SyncedFromLabel] != from {
+		logrus.Debugf("sync: keeping %s in namespace %s, not synced from %s", name, to, from)
+		return nil
+	}
+	if policy == SyncDeleteUnused {
+		container, err := i.namespaceContainer(ctx, to, name)
+		if err != nil {
+			return err
+		}
+		if container != "" {
+			logrus.Infof("sync: keeping %s in namespace %s, used by container %s", name, to, container)
+			return nil
+		}
+	}
+	logrus.Infof("sync: deleting %s from namespace %s, deleted from %s", name, to, from)
+	if err = i.Containerd.ImageService().Delete(ctx, name); errdefs.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// namespaceContainer returns the ID of a container of the namespace using the named image, if any, those of the image
+// namespace being looked up as by the removal of images
+func (i *Interface) namespaceContainer(ctx context.Context, ns, name string) (string, error) {
+	if ns == i.imageNamespace() {
+		image, err := i.imageStatus(ctx, name)
+		if err != nil || image == nil {
+			return "", err
+		}
+		return i.imageContainer(ctx, image)
+	}
+	containers, err := i.Containerd.ContainerService().List(namespaces.WithNamespace(ctx, ns))
+	if err != nil {
+		return "", err
+	}
+	for _, container := range containers {
+		if container.Image == name {
+			return container.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// taggedImage returns the image labeled as tagged from the namespace
+func taggedImage(img images.Image, from string) images.Image {
+	labels := map[string]string{}
+	for k, v := range img.Labels {
+		labels[k] = v
+	}
+	labels[TaggedFromLabel] = from
+	img.Labels = labels
+	return img
+}
+
+// builtImage returns whether the image of the buildkit namespace, by its labels, was exported by a build rather than
+// tagged, copied or synced there by k3c
+func builtImage(labels map[string]string) bool {
+	return labels[TaggedFromLabel] == "" && labels[SyncedFromLabel] == ""
+}
+
+// syncedImage returns the image labeled as synced from the namespace
+func syncedImage(img images.Image, from string) images.Image {
+	labels := map[string]string{}
+	for k, v := range img.Labels {
+		labels[k] = v
+	}
+	labels[SyncedFromLabel] = from
+	img.Labels = labels
+	return img
+}
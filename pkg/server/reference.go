@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+// imageIDPattern matches image IDs and prefixes of them, with or without the algorithm
+var imageIDPattern = regexp.MustCompile(`^(?:sha256:)?([a-f0-9]{1,64})$`)
+
+// requestImage returns the image of the request, which is required
+func requestImage(spec *criv1.ImageSpec) (string, error) {
+	ref := spec.GetImage()
+	if ref == "" {
+		return "", status.Error(codes.InvalidArgument, "an image is required")
+	}
+	return ref, nil
+}
+
+// normalizeReference normalizes the reference as the docker cli would, e.g. `myapp` is `docker.io/library/myapp:latest`
+func normalizeReference(ref string) (string, error) {
+	named, err := refdocker.ParseDockerRef(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid reference %q", ref)
+	}
+	return named.String(), nil
+}
+
+// normalizeTag normalizes the reference of a tag to be created, which cannot have a digest
+func normalizeTag(tag string) (string, error) {
+	named, err := refdocker.ParseDockerRef(tag)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid tag %q", tag)
+	}
+	if _, ok := named.(refdocker.Digested); ok {
+		return "", errors.Errorf("invalid tag %q: tags cannot have a digest", tag)
+	}
+	return named.String(), nil
+}
+
+// lookupImage returns the image record for the reference, being a (familiar) name, a name with a digest or an image ID
+// or unique prefix of one. Names take precedence over ID prefixes as with the docker cli.
+func (i *Interface) lookupImage(ctx context.Context, ref string) (images.Image, error) {
+	svc := i.Containerd.ImageService()
+	named, err := refdocker.ParseDockerRef(ref)
+	if err == nil {
+		img, err := svc.Get(ctx, named.String())
+		if err == nil || !errdefs.IsNotFound(err) {
+			return img, err
+		}
+	}
+	match := imageIDPattern.FindStringSubmatch(ref)
+	if match == nil {
+		if err != nil {
+			return images.Image{}, errors.Wrapf(err, "invalid reference %q", ref)
+		}
+		return images.Image{}, errors.Wrapf(errdefs.ErrNotFound, "image %q", ref)
+	}
+	// the cri names images by the digest of their config, i.e. their ID, other namespaces are searched by the IDs
+	list, err := svc.List(ctx, `name~="^sha256:`+match[1]+`"`)
+	if err == nil && len(list) == 0 {
+		list, err = i.imagesByIDPrefix(ctx, match[1])
+	}
+	if err != nil {
+		return images.Image{}, err
+	}
+	switch len(list) {
+	case 0:
+		return images.Image{}, errors.Wrapf(errdefs.ErrNotFound, "image %q", ref)
+	case 1:
+		return list[0], nil
+	default:
+		return images.Image{}, errors.Errorf("ambiguous image ID prefix %q matches %d images", ref, len(list))
+	}
+}
+
+// imageFilter returns a predicate matching the images listed by the cri against the reference. A name alone matches
+// all tags and digests of the repository, a tag or digest only matches that reference and an ID prefix matches the
+// images with that ID.
+func imageFilter(ref string) (func(*criv1.Image) bool, error) {
+	var (
+		named, err = refdocker.ParseNormalizedNamed(ref)
+		match      = imageIDPattern.FindStringSubmatch(ref)
+	)
+	if err != nil && match == nil {
+		return nil, errors.Wrapf(err, "invalid reference %q", ref)
+	}
+	return func(image *criv1.Image) bool {
+		if match != nil && strings.HasPrefix(strings.TrimPrefix(image.Id, "sha256:"), match[1]) {
+			return true
+		}
+		if named == nil {
+			return false
+		}
+		for _, name := range append(append([]string{}, image.RepoTags...), image.RepoDigests...) {
+			other, err := refdocker.ParseNormalizedNamed(name)
+			if err != nil {
+				continue
+			}
+			if refdocker.IsNameOnly(named) {
+				if other.Name() == named.Name() {
+					return true
+				}
+			} else if other.String() == named.String() {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
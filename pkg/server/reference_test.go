@@ -0,0 +1,91 @@
+package server
+
+import (
+	"testing"
+
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestNormalizeReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{ref: "myapp", want: "docker.io/library/myapp:latest"},
+		{ref: "rancher/k3c:v0.1", want: "docker.io/rancher/k3c:v0.1"},
+		{ref: "registry.local:5000/app", want: "registry.local:5000/app:latest"},
+		{ref: "app@" + testDigest, want: "docker.io/library/app@" + testDigest},
+		{ref: "Invalid/App", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := normalizeReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeReference(%q) returned error %v, expected an error: %t", tt.ref, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeReference(%q) = %q, expected %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{tag: "myapp", want: "docker.io/library/myapp:latest"},
+		{tag: "registry.local/app:dev", want: "registry.local/app:dev"},
+		{tag: "app@" + testDigest, wantErr: true},
+		{tag: "app:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := normalizeTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeTag(%q) returned error %v, expected an error: %t", tt.tag, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeTag(%q) = %q, expected %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageFilter(t *testing.T) {
+	image := &criv1.Image{
+		Id:          testDigest,
+		RepoTags:    []string{"docker.io/library/app:1.0", "registry.local/app:dev"},
+		RepoDigests: []string{"docker.io/library/app@sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"},
+	}
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{ref: "app", want: true},
+		{ref: "app:1.0", want: true},
+		{ref: "app:2.0"},
+		{ref: "registry.local/app", want: true},
+		{ref: "app@sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210", want: true},
+		{ref: "other"},
+		{ref: "0123456", want: true},
+		{ref: "sha256:0123456", want: true},
+		{ref: "abcdef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			filter, err := imageFilter(tt.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter(image); got != tt.want {
+				t.Errorf("filter of %q matches: %t, expected %t", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
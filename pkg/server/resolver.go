@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/rancher/k3c/pkg/registries"
+	"github.com/rancher/k3c/pkg/version"
+	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+// resolver returns a registry resolver authenticating with the passed auth configs, tracking progress with the
+// passed tracker (if not nil) and counting the bytes transferred for the operation. Mirrors, TLS and credentials from
+// the registries configuration are honored, as are the registry credentials stored in-cluster.
+func (i *Interface) resolver(operation string, authConfigs []*criv1.AuthConfig, tracker docker.StatusTracker) (remotes.Resolver, error) {
+	registry, err := registries.Load(i.config.RegistriesFile)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{
+		"User-Agent": []string{fmt.Sprintf("k3c/%s", version.Version)},
+	}
+	credentials, err := i.credentials(authConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return docker.NewResolver(docker.ResolverOptions{
+		Tracker: tracker,
+		Hosts: registry.Hosts(registries.HostOptions{
+			Credentials: credentials,
+			Tokens:      tokens(authConfigs),
+			Header:      header,
+			Transport:   i.transport(operation),
+		}),
+	}), nil
+}
+
+// transport wraps the registry transport with retries of transient failures, resuming interrupted blob transfers, each
+// attempt being throttled by the bandwidth limit shared by all transfers and metered.
+func (i *Interface) transport(operation string) func(http.RoundTripper) http.RoundTripper {
+	sent, received := registrySentBytes.WithLabelValues(operation), registryReceivedBytes.WithLabelValues(operation)
+	counting := registries.Counting(func(n int) { sent.Add(float64(n)) }, func(n int) { received.Add(float64(n)) })
+	return func(next http.RoundTripper) http.RoundTripper {
+		return registries.Retrying(i.config.Retries)(registries.RateLimited(i.limiter)(counting(next)))
+	}
+}
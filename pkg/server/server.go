@@ -2,12 +2,14 @@ package server
 
 import (
 	"sync"
+	"time"
 
 	"github.com/containerd/containerd"
 	buildkit "github.com/moby/buildkit/client"
 	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
 	"github.com/rancher/k3c/pkg/client"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	criv1 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
@@ -19,7 +21,11 @@ type Interface struct {
 	Containerd     *containerd.Client
 	RuntimeService criv1.RuntimeServiceClient
 	ImageService   criv1.ImageServiceClient
-	pushes         sync.Map
+	config         *Config
+	limiter        *rate.Limiter
+	buildsMu       sync.Mutex
+	lastBuild      time.Time
+	ingestPoll     time.Duration
 }
 
 // Close the Interface connections to various backends.
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"io/ioutil"
+	"regexp"
+
+	"github.com/containerd/containerd/identifiers"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// SyncRules is the configuration of the images synced by the agent between containerd namespaces, e.g.
+//
+//	rules:
+//	- from: buildkit
+//	  to: [k8s.io, moby]
+//	  exclude: ["^docker.io/library/scratch-"]
+//	  selector: "k3c.cattle.io/sync!=false"
+//	  deletePolicy: delete-unused
+type SyncRules struct {
+	Rules []SyncRule `json:"rules"`
+}
+
+// SyncRule syncs the images of a namespace, matching the name patterns and label selector, to other namespaces
+type SyncRule struct {
+	// From is the namespace to sync images from, defaults to the buildkit namespace.
+	From string `json:"from"`
+	// To are the namespaces to sync images to, defaults to k8s.io.
+	To []string `json:"to"`
+	// Include are regular expressions of the names of the images to sync, defaults to all images.
+	Include []string `json:"include,omitempty"`
+	// Exclude are regular expressions of the names of the images not to sync, taking precedence over Include.
+	Exclude []string `json:"exclude,omitempty"`
+	// Selector is a label query of the images to sync, e.g. `k3c.cattle.io/sync!=false`.
+	Selector string `json:"selector,omitempty"`
+	// DeletePolicy is the policy for the copies of images deleted from the namespace, defaults to --sync-delete-policy.
+	DeletePolicy string `json:"deletePolicy,omitempty"`
+
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+	selector labels.Selector
+}
+
+// LoadSyncRules loads the sync rules from the file, defaulting the fields of the rules to those of the default rule,
+// which is the only rule without a file.
+func LoadSyncRules(path string, defaults SyncRule) (*SyncRules, error) {
+	rules := &SyncRules{}
+	if path == "" {
+		rules.Rules = []SyncRule{defaults}
+	} else {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, rules); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", path)
+		}
+	}
+	for i := range rules.Rules {
+		if err := rules.Rules[i].complete(defaults); err != nil {
+			return nil, errors.Wrapf(err, "invalid sync rule %d", i+1)
+		}
+	}
+	return rules, nil
+}
+
+// Sources returns the distinct namespaces that images are synced from
+func (s *SyncRules) Sources() []string {
+	var sources []string
+	seen := map[string]bool{}
+	for _, rule := range s.Rules {
+		if !seen[rule.From] {
+			seen[rule.From] = true
+			sources = append(sources, rule.From)
+		}
+	}
+	return sources
+}
+
+// Namespaces returns the distinct namespaces that images are synced from or to
+func (s *SyncRules) Namespaces() []string {
+	var nss []string
+	seen := map[string]bool{}
+	for _, rule := range s.Rules {
+		for _, ns := range append([]string{rule.From}, rule.To...) {
+			if !seen[ns] {
+				seen[ns] = true
+				nss = append(nss, ns)
+			}
+		}
+	}
+	return nss
+}
+
+func (r *SyncRule) complete(defaults SyncRule) error {
+	if r.From == "" {
+		r.From = defaults.From
+	}
+	if len(r.To) == 0 {
+		r.To = defaults.To
+	}
+	if r.Selector == "" {
+		r.Selector = defaults.Selector
+	}
+	if r.DeletePolicy == "" {
+		r.DeletePolicy = defaults.DeletePolicy
+	}
+	for _, ns := range append([]string{r.From}, r.To...) {
+		if err := identifiers.Validate(ns); err != nil {
+			return errors.Wrapf(err, "invalid namespace %q", ns)
+		}
+	}
+	for _, ns := range r.To {
+		if ns == r.From {
+			return errors.Errorf("namespace %s is synced to itself", ns)
+		}
+	}
+	if !ValidSyncDeletePolicy(r.DeletePolicy) {
+		return errors.Errorf("invalid delete policy %q", r.DeletePolicy)
+	}
+	var err error
+	if r.include, err = compilePatterns(r.Include); err != nil {
+		return err
+	}
+	if r.exclude, err = compilePatterns(r.Exclude); err != nil {
+		return err
+	}
+	if r.selector, err = labels.Parse(r.Selector); err != nil {
+		return errors.Wrapf(err, "invalid selector %q", r.Selector)
+	}
+	return nil
+}
+
+// Matches returns whether the image of the name and labels is synced by the rule
+func (r *SyncRule) Matches(name string, imageLabels map[string]string) bool {
+	return r.MatchesName(name) && (r.selector == nil || r.selector.Matches(labels.Set(imageLabels)))
+}
+
+// MatchesName returns whether the name of the image matches the patterns of the rule, regardless of its labels
+func (r *SyncRule) MatchesName(name string) bool {
+	for _, pattern := range r.exclude {
+		if pattern.MatchString(name) {
+			return false
+		}
+	}
+	if len(r.include) == 0 {
+		return true
+	}
+	for _, pattern := range r.include {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
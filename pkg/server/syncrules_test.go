@@ -0,0 +1,138 @@
+package server
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadSyncRules(t *testing.T) {
+	defaults := SyncRule{
+		From:         "buildkit",
+		To:           []string{"k8s.io"},
+		DeletePolicy: SyncDeleteUnused,
+	}
+	tests := []struct {
+		name    string
+		rules   string
+		want    []SyncRule
+		wantErr bool
+	}{
+		{
+			name: "defaults without a file",
+			want: []SyncRule{defaults},
+		},
+		{
+			name: "fields default to those of the default rule",
+			rules: `
+rules:
+- to: [moby]
+- from: moby
+  deletePolicy: keep
+`,
+			want: []SyncRule{
+				{From: "buildkit", To: []string{"moby"}, DeletePolicy: SyncDeleteUnused},
+				{From: "moby", To: []string{"k8s.io"}, DeletePolicy: SyncDeleteKeep},
+			},
+		},
+		{
+			name:    "invalid namespace",
+			rules:   "rules: [{to: [not/a/namespace]}]",
+			wantErr: true,
+		},
+		{
+			name:    "synced to itself",
+			rules:   "rules: [{from: moby, to: [moby]}]",
+			wantErr: true,
+		},
+		{
+			name:    "invalid delete policy",
+			rules:   "rules: [{deletePolicy: sometimes}]",
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			rules:   `rules: [{include: ["("]}]`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			rules:   `rules: [{selector: "a b c"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			if tt.rules != "" {
+				path = filepath.Join(t.TempDir(), "rules.yaml")
+				if err := ioutil.WriteFile(path, []byte(tt.rules), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			rules, err := LoadSyncRules(path, defaults)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []SyncRule
+			for _, rule := range rules.Rules {
+				got = append(got, SyncRule{From: rule.From, To: rule.To, DeletePolicy: rule.DeletePolicy})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rules are %+v, expected %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncRuleMatches(t *testing.T) {
+	rule := SyncRule{
+		Include:  []string{`^docker\.io/library/`, `^registry\.local/`},
+		Exclude:  []string{`^docker\.io/library/scratch-`},
+		Selector: "k3c.cattle.io/sync!=false",
+	}
+	if err := rule.complete(SyncRule{From: "buildkit", To: []string{"k8s.io"}, DeletePolicy: SyncDeleteKeep}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		image  string
+		labels map[string]string
+		want   bool
+	}{
+		{name: "included", image: "docker.io/library/app:latest", want: true},
+		{name: "included by another pattern", image: "registry.local/app:latest", want: true},
+		{name: "not included", image: "quay.io/app:latest"},
+		{name: "excluded", image: "docker.io/library/scratch-123:latest"},
+		{name: "labeled not to sync", image: "docker.io/library/app:latest", labels: map[string]string{"k3c.cattle.io/sync": "false"}},
+		{name: "labeled otherwise", image: "docker.io/library/app:latest", labels: map[string]string{"k3c.cattle.io/sync": "true"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Matches(tt.image, tt.labels); got != tt.want {
+				t.Errorf("rule matches %s (labels %v): %t, expected %t", tt.image, tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncRulesNamespaces(t *testing.T) {
+	rules := SyncRules{Rules: []SyncRule{
+		{From: "buildkit", To: []string{"k8s.io", "moby"}},
+		{From: "moby", To: []string{"k8s.io"}},
+		{From: "buildkit", To: []string{"default"}},
+	}}
+	if got, want := rules.Sources(), []string{"buildkit", "moby"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sources are %v, expected %v", got, want)
+	}
+	if got, want := rules.Namespaces(), []string{"buildkit", "k8s.io", "moby", "default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("namespaces are %v, expected %v", got, want)
+	}
+}
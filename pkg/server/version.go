@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+
+	imagesv1 "github.com/rancher/k3c/pkg/apis/services/images/v1alpha1"
+	"github.com/rancher/k3c/pkg/version"
+	"github.com/sirupsen/logrus"
+)
+
+// Version server-side impl, the client checks compatibility with the api version of the agent and the agent logs that
+// of clients outside of the range it is compatible with.
+func (i *Interface) Version(_ context.Context, req *imagesv1.VersionRequest) (*imagesv1.VersionResponse, error) {
+	if req.ApiVersion < version.MinAPIVersion {
+		logrus.Warnf("version: client %s (api version %d) is older than the agent supports (api version %d)", req.Version, req.ApiVersion, version.MinAPIVersion)
+	} else {
+		logrus.Debugf("version: client %s (%s), api version %d, capabilities %v", req.Version, req.GitCommit, req.ApiVersion, req.Capabilities)
+	}
+	capabilities := append([]string{}, version.Capabilities...)
+	if i.config.Authorization {
+		capabilities = append(capabilities, version.CapabilityAuthorization)
+	}
+	return &imagesv1.VersionResponse{
+		Version:       version.Version,
+		GitCommit:     version.GitCommit,
+		ApiVersion:    version.APIVersion,
+		MinApiVersion: version.MinAPIVersion,
+		Capabilities:  capabilities,
+	}, nil
+}
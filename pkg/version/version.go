@@ -9,6 +9,36 @@ var (
 	GitCommit = "HEAD"
 )
 
+const (
+	// APIVersion is the version of the agent api, incremented on changes that break compatibility with older clients
+	APIVersion uint32 = 2
+	// MinAPIVersion is the oldest version of the agent api that clients and agents remain compatible with. Version 2
+	// streams the progress of pulls and pushes, which version 1 agents (predating the handshake) answered once.
+	MinAPIVersion uint32 = 2
+
+	// CapabilityStreamingProgress is the streaming of the progress of pulls and pushes
+	CapabilityStreamingProgress = "streaming-progress"
+	// CapabilityNamespaces is the tagging and copying of images between containerd namespaces
+	CapabilityNamespaces = "namespaces"
+	// CapabilityForceRemove is the forced removal of images
+	CapabilityForceRemove = "force-remove"
+	// CapabilityInfo is the info of the agent and its backends
+	CapabilityInfo = "info"
+	// CapabilityLogin is the verification of registry credentials
+	CapabilityLogin = "login"
+	// CapabilityAuthorization is the authorization of callers by their bearer token, only reported by agents requiring it
+	CapabilityAuthorization = "authorization"
+)
+
+// Capabilities are the capabilities of this version of the client and agent
+var Capabilities = []string{
+	CapabilityStreamingProgress,
+	CapabilityNamespaces,
+	CapabilityForceRemove,
+	CapabilityInfo,
+	CapabilityLogin,
+}
+
 func FriendlyVersion() string {
 	return fmt.Sprintf("%s (%s)", Version, GitCommit)
 }